@@ -39,15 +39,13 @@ func init() {
 					bln.String(),
 				))
 				out.WriteString("]")
-				fmt.Println(common.JSONPrettyFormat(out.String()))
-				return nil
+				return printResult(common.JSONPrettyFormat(out.String()), balanceView)
 			}
 			r, err := sharding.CheckAllShards(node, addr.String(), noPrettyOutput)
 			if err != nil {
 				return err
 			}
-			fmt.Println(r)
-			return nil
+			return printResult(r, balanceView)
 		},
 	}
 
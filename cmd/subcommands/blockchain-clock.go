@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/intelchain-itc/itc-sdk/pkg/clock"
+	"github.com/intelchain-itc/itc-sdk/pkg/common"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	clockWindow  uint64
+	clockAtBlock uint64
+	clockAtEpoch uint64
+)
+
+// parseTime reads a unix timestamp, an RFC 3339 time or a date, the start of
+// the day in UTC
+func parseTime(s string) (time.Time, error) {
+	if unix, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Unix(unix, 0).UTC(), nil
+	}
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return t, errors.Errorf("%s is neither a unix timestamp, an RFC 3339 time nor a date", s)
+	}
+	return t, nil
+}
+
+func epochInfoCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "epoch-info",
+		Short: "current epoch, blocks left in it and estimated times of later blocks and epochs",
+		Args:  cobra.ExactArgs(0),
+		Long: `
+Report the current epoch of the shard of --node, its first and last block,
+the blocks left in it and the average block time over the last --window
+blocks, with estimates of when the epoch ends, when --block is made and when
+--epoch begins.
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := clock.New(clock.RPCChain{Node: node}, clockWindow)
+			if err != nil {
+				return err
+			}
+			info := map[string]interface{}{
+				"block":               c.Latest.Number,
+				"block-time":          c.Latest.Time,
+				"epoch":               c.Latest.Epoch,
+				"epoch-first-block":   c.EpochStart,
+				"epoch-last-block":    c.EpochLastBlock(),
+				"blocks-left":         c.BlocksLeft(),
+				"blocks-per-epoch":    c.BlocksPerEpoch,
+				"average-block-time":  c.BlockTime.String(),
+				"block-time-window":   c.Window,
+				"estimated-epoch-end": c.BlockTimeOf(c.EpochLastBlock()),
+			}
+			if cmd.Flags().Changed("block") {
+				info["estimated-time-of-block"] = map[string]interface{}{
+					"block": clockAtBlock, "time": c.BlockTimeOf(clockAtBlock),
+				}
+			}
+			if cmd.Flags().Changed("epoch") {
+				if clockAtEpoch <= c.Latest.Epoch {
+					return fmt.Errorf("epoch %d has already begun, the current epoch is %d", clockAtEpoch, c.Latest.Epoch)
+				}
+				info["estimated-start-of-epoch"] = map[string]interface{}{
+					"epoch": clockAtEpoch, "first-block": c.EpochFirstBlock(clockAtEpoch), "time": c.EpochTime(clockAtEpoch),
+				}
+			}
+			fmt.Println(common.ToJSONUnsafe(info, !noPrettyOutput))
+			return nil
+		},
+	}
+
+	cmd.Flags().Uint64Var(&clockWindow, "window", clock.DefaultWindow, "latest blocks to average the block time over")
+	cmd.Flags().Uint64Var(&clockAtBlock, "block", 0, "estimate when this block is made")
+	cmd.Flags().Uint64Var(&clockAtEpoch, "epoch", 0, "estimate when this epoch begins")
+	return cmd
+}
+
+func blockAtTimeCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "block-at-time <unix-timestamp|RFC3339-time>",
+		Short: "find the block made closest to a time",
+		Args:  cobra.ExactArgs(1),
+		Long: `
+Binary search the blocks of the shard of --node for the one made closest to
+a time, given as a unix timestamp or as an RFC 3339 time such as
+2024-01-31T12:00:00Z. Times after the latest block find the latest block.
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			at, err := parseTime(args[0])
+			if err != nil {
+				return err
+			}
+			block, err := clock.BlockAtTime(clock.RPCChain{Node: node}, at)
+			if err != nil {
+				return err
+			}
+			fmt.Println(common.ToJSONUnsafe(map[string]interface{}{
+				"block":      block.Number,
+				"epoch":      block.Epoch,
+				"block-time": block.Time,
+				"offset":     block.Time.Sub(at).String(),
+			}, !noPrettyOutput))
+			return nil
+		},
+	}
+}
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/intelchain-itc/itc-sdk/pkg/history"
+	"github.com/intelchain-itc/itc-sdk/pkg/rpc"
+	"github.com/intelchain-itc/itc-sdk/pkg/sharding"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	historyShards       []int
+	historyStaking      bool
+	historySince        string
+	historyUntil        string
+	historyCounterparty itcAddress
+	historyDirection    string
+	historyFormat       string
+	historyOut          string
+	historyPageSize     int
+	historyMaxTx        int
+)
+
+// historyFilter is the filter of the history flags
+func historyFilter() (history.Filter, error) {
+	filter := history.Filter{Counterparty: historyCounterparty.address, Direction: historyDirection}
+	switch historyDirection {
+	case "", history.DirectionIn, history.DirectionOut, history.DirectionSelf:
+	default:
+		return filter, errors.Errorf("unknown direction %s, expected %s, %s or %s",
+			historyDirection, history.DirectionIn, history.DirectionOut, history.DirectionSelf)
+	}
+	var err error
+	if historySince != "" {
+		if filter.Since, err = parseTime(historySince); err != nil {
+			return filter, err
+		}
+	}
+	if historyUntil != "" {
+		if filter.Until, err = parseTime(historyUntil); err != nil {
+			return filter, err
+		}
+	}
+	return filter, nil
+}
+
+// accountHistory fetches the history of account on the selected shards,
+// filtered and, with receipts set, enriched with their receipts, oldest first
+func accountHistory(
+	account string, shards []int, staking bool, filter history.Filter, receipts bool,
+) ([]history.Transaction, error) {
+	routes, err := sharding.Structure(node)
+	if err != nil {
+		return nil, err
+	}
+	selected := map[int]bool{}
+	for _, shard := range shards {
+		selected[shard] = true
+	}
+	nodes := map[uint32]string{}
+	for _, route := range routes {
+		nodes[uint32(route.ShardID)] = route.HTTP
+	}
+	for shard := range selected {
+		if _, ok := nodes[uint32(shard)]; !ok {
+			return nil, errors.Errorf("no shard %d, the chain has %d shards", shard, len(routes))
+		}
+	}
+
+	txs := []history.Transaction{}
+	query := history.Query{Address: account, PageSize: historyPageSize, Staking: staking, Since: filter.Since}
+	for _, route := range routes {
+		if len(selected) > 0 && !selected[route.ShardID] {
+			continue
+		}
+		shardTxs, err := history.Fetch(route.HTTP, query, uint32(route.ShardID))
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not fetch the history on shard %d", route.ShardID)
+		}
+		txs = append(txs, shardTxs...)
+	}
+	txs = filter.Apply(txs)
+	if !receipts {
+		return txs, nil
+	}
+	if err := history.Enrich(nodes, txs); err != nil {
+		return nil, errors.Wrap(err, "could not fetch receipts")
+	}
+	return txs, nil
+}
+
+func accountHistoryCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "account-history <address>",
+		Short: "Get history of all transactions for given account",
+		Long: `
+Page through the full transaction history of an account on every shard, or
+the shards of --shards, with its staking transactions when --staking is set.
+Transactions can be narrowed to a date range with --since and --until, unix
+timestamps or RFC 3339 times, to a --counterparty and to a --direction of in,
+out or self.
+
+The node's transaction objects are printed oldest first in --output. --format
+csv or jsonl instead exports a record per transaction to --out or standard
+output for accounting, with the direction, counterparty, and the fee paid and
+status from its receipt. --max-tx keeps only the most recent transactions,
+noting on standard error how many were left out.
+`,
+		Args:    cobra.ExactArgs(1),
+		PreRunE: validateAddress,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			switch historyFormat {
+			case "", history.CSV, history.JSONL:
+			default:
+				return errors.Errorf("unknown format %s, expected %s, %s or --output",
+					historyFormat, history.CSV, history.JSONL)
+			}
+			filter, err := historyFilter()
+			if err != nil {
+				return err
+			}
+			txs, err := accountHistory(addr.address, historyShards, historyStaking, filter, historyFormat != "")
+			if err != nil {
+				return err
+			}
+			if historyMaxTx > 0 && len(txs) > historyMaxTx {
+				fmt.Fprintf(os.Stderr, "leaving out the %d oldest of %d transactions, raise --max-tx or set it to 0 for all\n",
+					len(txs)-historyMaxTx, len(txs))
+				txs = txs[len(txs)-historyMaxTx:]
+			}
+
+			if historyFormat == "" {
+				result := map[string][]json.RawMessage{"transactions": {}}
+				if historyStaking {
+					result["staking_transactions"] = []json.RawMessage{}
+				}
+				for _, t := range txs {
+					key := "transactions"
+					if t.Kind != history.KindTransfer {
+						key = "staking_transactions"
+					}
+					result[key] = append(result[key], t.Raw)
+				}
+				return printValue(result, viewOf(rpc.Method.GetTransactionsHistory))
+			}
+			w := os.Stdout
+			if historyOut != "" {
+				if w, err = os.Create(historyOut); err != nil {
+					return err
+				}
+				defer w.Close()
+			}
+			return history.Write(w, historyFormat, txs)
+		},
+	}
+
+	cmd.Flags().IntSliceVar(&historyShards, "shards", nil, "shards to page through, all when unset")
+	cmd.Flags().BoolVar(&historyStaking, "staking", false, "include staking transactions")
+	cmd.Flags().StringVar(&historySince, "since", "", "only transactions at or after this time")
+	cmd.Flags().StringVar(&historyUntil, "until", "", "only transactions before this time")
+	cmd.Flags().Var(&historyCounterparty, "counterparty", "only transactions with this address")
+	cmd.Flags().StringVar(&historyDirection, "direction", "", "only transactions in this direction: in, out or self")
+	cmd.Flags().StringVar(&historyFormat, "format", "", "export as csv or jsonl records with fees and status instead of --output")
+	cmd.Flags().StringVar(&historyOut, "out", "", "file to export to instead of standard output")
+	cmd.Flags().IntVar(&historyPageSize, "page-size", 100, "transactions to request per page")
+	cmd.Flags().IntVar(&historyMaxTx, "max-tx", 0, "max number of most recent transactions to list, all when 0")
+	return cmd
+}
@@ -10,7 +10,6 @@ import (
 
 var (
 	addr itcAddress
-	size int64
 )
 
 func init() {
@@ -50,31 +49,6 @@ Query Intelchain's blockchain for completed transaction, historic records
 		},
 	}
 
-	accountHistorySubCmd := &cobra.Command{
-		Use:   "account-history",
-		Short: "Get history of all transactions for given account",
-		Long: `
-High level information about each transaction for given account
-`,
-		Args:    cobra.ExactArgs(1),
-		PreRunE: validateAddress,
-		RunE: func(cmd *cobra.Command, args []string) error {
-			type historyParams struct {
-				Address   string `json:"address"`
-				PageIndex int64  `json:"pageIndex"`
-				PageSize  int64  `json:"pageSize"`
-				FullTx    bool   `json:"fullTx"`
-				TxType    string `json:"txType"`
-				Order     string `json:"order"`
-			}
-			noLatest = true
-			params := historyParams{args[0], 0, size, true, "", ""}
-			return request(rpc.Method.GetTransactionsHistory, []interface{}{params})
-		},
-	}
-
-	accountHistorySubCmd.Flags().Int64Var(&size, "max-tx", 1000, "max number of transactions to list")
-
 	subCommands := []*cobra.Command{{
 		Use:   "block-by-number",
 		Short: "Get a Intelchain blockchain block by block number",
@@ -155,6 +129,13 @@ High level information about transaction, like blockNumber, blockHash
 			noLatest = true
 			return request(rpc.Method.GetPendingTxnsInPool, []interface{}{})
 		},
+	}, {
+		Use:   "committees",
+		Short: "Members of the current and previous committees of every shard",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			noLatest = true
+			return request(rpc.Method.GetSuperCommmittees, []interface{}{})
+		},
 	}, {
 		Use:   "latest-header",
 		Short: "Get the latest header",
@@ -186,13 +167,16 @@ High level information about transaction, like blockNumber, blockHash
 			return request(rpc.Method.GetCurrentUtilityMetrics, []interface{}{})
 		},
 	},
-		accountHistorySubCmd,
+		accountHistoryCommand(),
 	}
 
 	cmdBlockchain.AddCommand(cmdValidator)
 	cmdBlockchain.AddCommand(cmdDelegation)
 	cmdValidator.AddCommand(validatorSubCmds[:]...)
+	cmdValidator.AddCommand(validatorRankCommand(), validatorWatchCommand())
 	cmdDelegation.AddCommand(delegationSubCmds[:]...)
+	cmdDelegation.AddCommand(pendingUndelegationsCommand())
 	cmdBlockchain.AddCommand(subCommands[:]...)
+	cmdBlockchain.AddCommand(epochInfoCommand(), blockAtTimeCommand())
 	RootCmd.AddCommand(cmdBlockchain)
 }
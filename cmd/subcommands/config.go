@@ -0,0 +1,294 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/intelchain-itc/itc-sdk/pkg/common"
+	"github.com/intelchain-itc/itc-sdk/pkg/config"
+	"github.com/intelchain-itc/itc-sdk/pkg/doctor"
+	"github.com/intelchain-itc/itc-sdk/pkg/sharding"
+	"github.com/intelchain-itc/itc-sdk/pkg/store"
+	"github.com/intelchain-itc/itc-sdk/pkg/validation"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	profileName      string
+	cmdConfig        *cobra.Command
+	configProfile    config.Profile
+	configShardNodes map[string]string
+	configDefault    bool
+	configOffline    bool
+	verifiedNodes    = map[string]bool{}
+)
+
+// profileFlagDefaults are the flags of a command a profile fills in when
+// they are not given
+func profileFlagDefaults(p config.Profile) map[string]string {
+	return map[string]string{
+		"from":           p.From,
+		"delegator-addr": p.From,
+		"gas-price":      p.GasPrice,
+		"gas-limit":      p.GasLimit,
+	}
+}
+
+// applyConfig registers the custom chains of the config directory and sets
+// what --profile, or else the default profile, holds for everything not
+// given on the command line
+func applyConfig(cmd *cobra.Command) error {
+	if cmd.Parent() == cmdConfig {
+		// config commands must run even when the config file is broken
+		return nil
+	}
+	if err := config.RegisterChains(config.ChainsPath()); err != nil {
+		return err
+	}
+	conf, err := config.Load(config.DefaultPath())
+	if err != nil {
+		return err
+	}
+	p, ok, err := conf.Profile(profileName)
+	if err != nil || !ok {
+		return err
+	}
+	flags := cmd.Flags()
+	// the chain ID and endpoints of the profile belong to its nodes, so an
+	// explicit --node of another network is not rerouted or signed for them
+	node0, ok := p.Nodes[0]
+	ownNetwork := !flags.Changed("node") || (ok && node0 == node)
+	if ok && ownNetwork {
+		node = node0
+	}
+	if p.RPCPrefix != "" && !flags.Changed("rpc-prefix") {
+		rpcPrefix = p.RPCPrefix
+	}
+	if p.ChainID != "" && targetChain == "" && ownNetwork {
+		targetChain = p.ChainID
+	}
+	if p.From != "" && validation.ValidateAddress(p.From) != nil {
+		from, err := store.AddressFromAccountName(p.From)
+		if err != nil {
+			return errors.Wrapf(err, "could not find the account %s of the profile", p.From)
+		}
+		p.From = from
+	}
+	for name, value := range profileFlagDefaults(p) {
+		if f := flags.Lookup(name); f != nil && value != "" && !f.Changed {
+			if err := flags.Set(name, value); err != nil {
+				return errors.Wrapf(err, "invalid %s in the profile", name)
+			}
+		}
+	}
+	if ownNetwork {
+		sharding.SetEndpointRewrites(p.Rewrites)
+		sharding.SetShardEndpoints(p.Nodes)
+	}
+	return nil
+}
+
+// customChainServing returns the custom chain with node among its endpoints
+func customChainServing(node string) (common.CustomChain, bool) {
+	for _, chain := range common.CustomChains() {
+		for _, endpoint := range chain.Endpoints {
+			if endpoint == node {
+				return chain, true
+			}
+		}
+	}
+	return common.CustomChain{}, false
+}
+
+// verifyCustomChain checks, once per node, that node serves the custom chain
+// the CLI signs for before anything is signed for it
+func verifyCustomChain(node string) error {
+	if chainName.chainID == nil || verifiedNodes[node] {
+		return nil
+	}
+	chain, ok := common.LookupCustomChain(chainName.chainID.Name)
+	if !ok {
+		return nil
+	}
+	if err := config.VerifyChain(node, chain); err != nil {
+		return errors.Wrap(err, "refusing to sign")
+	}
+	verifiedNodes[node] = true
+	return nil
+}
+
+func configCreateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create <name>",
+		Short: "add a profile to the config file",
+		Args:  cobra.ExactArgs(1),
+		Long: `
+Add a profile to ~/.itc_cli/config.yaml. Nodes are given per shard with
+--shard-node 0=<URL>,1=<URL>, or else --node is the node of shard 0.
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := config.RegisterChains(config.ChainsPath()); err != nil {
+				return err
+			}
+			path := config.DefaultPath()
+			conf, err := config.Load(path)
+			if err != nil {
+				return err
+			}
+			p := configProfile
+			p.Nodes = map[int]string{0: node}
+			if len(configShardNodes) > 0 {
+				p.Nodes = map[int]string{}
+				for shard, url := range configShardNodes {
+					shardID, err := strconv.Atoi(shard)
+					if err != nil {
+						return errors.Errorf("shard %s of --shard-node is not a number", shard)
+					}
+					p.Nodes[shardID] = url
+				}
+			}
+			if problems := p.Validate(); len(problems) > 0 {
+				return errors.Errorf("invalid profile: %s", problems[0])
+			}
+			if err := conf.Add(args[0], p); err != nil {
+				return err
+			}
+			if configDefault || conf.DefaultProfile == "" {
+				conf.DefaultProfile = args[0]
+			}
+			if err := conf.Save(path); err != nil {
+				return err
+			}
+			fmt.Printf("Added profile %s to %s\n", args[0], path)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringToStringVar(&configShardNodes, "shard-node", map[string]string{}, "node URL per shard, as shard=URL")
+	cmd.Flags().StringVar(&configProfile.ChainID, "chain-id", "", "chain ID to sign for")
+	cmd.Flags().StringVar(&configProfile.RPCPrefix, "rpc-prefix", "", "RPC prefix, itc or eth")
+	cmd.Flags().StringVar(&configProfile.From, "from", "", "default sender, an address or a local account name")
+	cmd.Flags().StringVar(&configProfile.GasPrice, "gas-price", "", "default gas price")
+	cmd.Flags().StringVar(&configProfile.GasLimit, "gas-limit", "", "default gas limit")
+	cmd.Flags().StringToStringVar(&configProfile.Rewrites, "rewrite", map[string]string{},
+		"endpoints of the sharding structure to replace, as reported=replacement")
+	cmd.Flags().BoolVar(&configDefault, "default", false, "make it the default profile")
+	return cmd
+}
+
+func configShowCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show [name]",
+		Short: "print a profile, or the whole config file",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			conf, err := config.Load(config.DefaultPath())
+			if err != nil {
+				return err
+			}
+			var shown interface{} = conf
+			if len(args) == 1 {
+				p, _, err := conf.Profile(args[0])
+				if err != nil {
+					return err
+				}
+				shown = p
+			}
+			out, err := yaml.Marshal(shown)
+			if err != nil {
+				return err
+			}
+			fmt.Print(string(out))
+			return nil
+		},
+	}
+}
+
+func configValidateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate [name]",
+		Short: "check a profile, or every profile, and probe its nodes",
+		Args:  cobra.MaximumNArgs(1),
+		Long: `
+Check the custom chains of ~/.itc_cli/chains.yaml, then the profiles of the
+config file for missing nodes, malformed URLs, unknown chain IDs and bad gas
+defaults, then probe their nodes as utility doctor does, flagging nodes on
+another chain than the profile signs for or serving another shard than they
+are listed for. --offline skips the probes.
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := config.RegisterChains(config.ChainsPath()); err != nil {
+				return err
+			}
+			conf, err := config.Load(config.DefaultPath())
+			if err != nil {
+				return err
+			}
+			names := conf.Names()
+			if len(args) == 1 {
+				if _, _, err := conf.Profile(args[0]); err != nil {
+					return err
+				}
+				names = args
+			}
+			valid := true
+			for _, name := range names {
+				p := conf.Profiles[name]
+				fmt.Printf("Profile %s\n", name)
+				for _, problem := range p.Validate() {
+					fmt.Printf("  ERROR   %s\n", problem)
+					valid = false
+				}
+				if configOffline {
+					continue
+				}
+				expect := doctor.Expect{MaxLatency: 2 * time.Second, MaxBlockAge: time.Minute, MaxHeightLag: 5}
+				if chain, err := common.StringToChainID(p.ChainID); p.ChainID != "" && err == nil {
+					expect.ChainID, expect.Network = chain.Value, chain.Name
+				}
+				endpoints := []doctor.Endpoint{}
+				for shard, url := range p.Nodes {
+					endpoints = append(endpoints, doctor.Endpoint{Name: fmt.Sprintf("shard-%d", shard), URL: url, ShardID: shard})
+				}
+				sort.Slice(endpoints, func(i, j int) bool { return endpoints[i].ShardID < endpoints[j].ShardID })
+				report := doctor.Examine(endpoints, expect)
+				for _, f := range report.Findings {
+					fmt.Printf("  %-7s %s: %s\n", strings.ToUpper(f.Severity), f.Endpoint, f.Message)
+				}
+				valid = valid && report.Healthy()
+			}
+			if !valid {
+				return errors.New("the config has problems")
+			}
+			fmt.Println("No problems found")
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&configOffline, "offline", false, "only check the config file, do not probe the nodes")
+	return cmd
+}
+
+func init() {
+	cmdConfig = &cobra.Command{
+		Use:   "config",
+		Short: "manage the network profiles of ~/.itc_cli/config.yaml",
+		Long: `
+Profiles hold the nodes per shard, chain ID, RPC prefix, default sender, gas
+defaults and endpoint rewrites of a network. Select one with --profile, or
+set default-profile in the config file; flags given on the command line take
+precedence over the profile.
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.Help()
+			return nil
+		},
+	}
+	cmdConfig.AddCommand(configCreateCommand(), configShowCommand(), configValidateCommand())
+	RootCmd.AddCommand(cmdConfig)
+}
@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/intelchain-itc/itc-sdk/pkg/clock"
+	"github.com/intelchain-itc/itc-sdk/pkg/delegation"
+	"github.com/intelchain-itc/itc-sdk/pkg/sharding"
+	"github.com/intelchain-itc/itc-sdk/pkg/validator"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const alertUndelegationSpendable = "undelegation-spendable"
+
+var (
+	pendingLockEpochs   uint64
+	pendingWatch        bool
+	pendingPollInterval time.Duration
+	pendingExec         string
+	pendingWebhooks     []string
+)
+
+// printLockups prints the undelegations as tables, or in --output when
+// asOutput is set
+func printLockups(
+	pending []delegation.PendingUndelegation, lockups []delegation.ValidatorLockup, c *clock.Clock, asOutput bool,
+) error {
+	epochEnds := c.EpochTime(c.Latest.Epoch + 1)
+	if asOutput {
+		return printValue(map[string]interface{}{
+			"epoch":         c.Latest.Epoch,
+			"epoch-ends":    epochEnds,
+			"epoch-length":  c.EpochLength().String(),
+			"undelegations": pending,
+			"validators":    lockups,
+		}, pendingUndelegationsView)
+	}
+	if len(pending) == 0 {
+		fmt.Println("No pending undelegations")
+		return nil
+	}
+	fmt.Printf("Epoch %d ends around %s, epochs last about %s\n\n",
+		c.Latest.Epoch, epochEnds.Local().Format(time.RFC1123), c.EpochLength().Round(time.Minute))
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VALIDATOR\tAMOUNT\tUNDELEGATED\tRELEASE EPOCH\tEPOCHS LEFT\tESTIMATED RELEASE")
+	for _, p := range pending {
+		release := p.Release.Local().Format(time.RFC1123)
+		if p.Spendable {
+			release = "spendable"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\t%s\n", p.Validator, p.Amount, p.Epoch, p.ReleaseEpoch, p.EpochsLeft, release)
+	}
+	w.Flush()
+	fmt.Println()
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VALIDATOR\tLOCKED\tENTRIES\tNEXT RELEASE")
+	for _, l := range lockups {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\n", l.Validator, l.Locked, l.Entries, l.NextRelease.Local().Format(time.RFC1123))
+	}
+	return w.Flush()
+}
+
+// pollLockups fetches the pending undelegations of delegator with the clock
+// estimating their release
+func pollLockups(beaconNode, delegator string) (
+	*clock.Clock, []delegation.PendingUndelegation, []delegation.ValidatorLockup, error,
+) {
+	c, err := clock.New(clock.RPCChain{Node: beaconNode}, clock.DefaultWindow)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "could not estimate epoch times")
+	}
+	delegations, err := delegation.ByDelegator(beaconNode, delegator)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	pending, lockups := delegation.Lockups(delegations, c, pendingLockEpochs, time.Now().UTC())
+	return c, pending, lockups, nil
+}
+
+func pendingUndelegationsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "pending-undelegations <delegator>",
+		Short:   "show when undelegated funds become spendable",
+		Args:    cobra.ExactArgs(1),
+		PreRunE: validateAddress,
+		Long: `
+List the undelegations of a delegator still locked, the epoch each becomes
+spendable in and an estimate of when, from the length of an epoch and the
+block time measured over the last blocks, with the locked total per
+validator. They are printed as tables, or in --output when it is given. With
+--watch keep polling and alert, as a JSON line and to --alert-exec and
+--alert-webhook, when an undelegation becomes spendable. Polls that fail are
+logged and retried at the next --poll-interval.
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			routes, err := sharding.Structure(node)
+			if err != nil {
+				return err
+			}
+			beaconNode := beaconChainNode(routes)
+			sinks := []validator.Sink{validator.WriterSink{W: os.Stdout}}
+			if pendingExec != "" {
+				sinks = append(sinks, validator.ExecSink{Command: pendingExec})
+			}
+			for _, url := range pendingWebhooks {
+				sinks = append(sinks, validator.WebhookSink{URL: url, Timeout: 10 * time.Second})
+			}
+
+			// undelegations still locked when last seen, alerted once they unlock
+			var locked map[delegation.PendingUndelegation]bool
+			for {
+				c, pending, lockups, err := pollLockups(beaconNode, addr.address)
+				if err != nil {
+					if !pendingWatch {
+						return err
+					}
+					fmt.Fprintf(os.Stderr, "could not poll the undelegations, retrying in %s: %s\n", pendingPollInterval, err)
+					time.Sleep(pendingPollInterval)
+					continue
+				}
+				if locked == nil {
+					if err := printLockups(pending, lockups, c, outputChanged(cmd)); err != nil {
+						return err
+					}
+				}
+				if !pendingWatch {
+					return nil
+				}
+				still := map[delegation.PendingUndelegation]bool{}
+				for _, p := range pending {
+					if !p.Spendable {
+						key := p
+						key.Release, key.EpochsLeft = time.Time{}, 0
+						still[key] = true
+					}
+				}
+				// spendable ones and ones already paid out are no longer locked
+				for p := range locked {
+					if still[p] {
+						continue
+					}
+					alert := validator.Alert{
+						Kind:      alertUndelegationSpendable,
+						Validator: p.Validator,
+						Message: fmt.Sprintf("%s ITC undelegated from %s in epoch %d is spendable",
+							p.Amount, p.Validator, p.Epoch),
+						Epoch: c.Latest.Epoch,
+						Time:  time.Now().UTC(),
+					}
+					for _, sink := range sinks {
+						if err := sink.Send(alert); err != nil {
+							fmt.Fprintln(os.Stderr, errors.Wrapf(err, "could not send %s alert", alert.Kind))
+						}
+					}
+				}
+				locked = still
+				time.Sleep(pendingPollInterval)
+			}
+		},
+	}
+
+	cmd.Flags().Uint64Var(&pendingLockEpochs, "lock-epochs", 7, "epochs undelegated funds stay locked")
+	cmd.Flags().BoolVar(&pendingWatch, "watch", false, "keep polling and alert when undelegations become spendable")
+	cmd.Flags().DurationVar(&pendingPollInterval, "poll-interval", time.Minute, "how often --watch polls")
+	cmd.Flags().StringVar(&pendingExec, "alert-exec", "", "shell command run per alert with the alert JSON on its standard input")
+	cmd.Flags().StringSliceVar(&pendingWebhooks, "alert-webhook", []string{}, "URLs the alert JSON is posted to")
+	return cmd
+}
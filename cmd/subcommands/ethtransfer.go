@@ -30,6 +30,7 @@ type ethTransferFlags struct {
 	InputNonce       *string `json:"nonce"`
 	GasPrice         *string `json:"gas-price"`
 	GasLimit         *string `json:"gas-limit"`
+	InputData        *string `json:"data"`
 	StopOnError      bool    `json:"stop-on-error"`
 	TrueNonce        bool    `json:"true-nonce"`
 }
@@ -47,6 +48,9 @@ func ethHandlerForTransaction(txLog *transactionLog) error {
 	if handlerForError(txLog, err) != nil {
 		return err
 	}
+	if err := verifyCustomChain(node); handlerForError(txLog, err) != nil {
+		return err
+	}
 
 	var ctrlr *transaction.EthController
 	if useLedgerWallet {
@@ -79,9 +83,14 @@ func ethHandlerForTransaction(txLog *transactionLog) error {
 		return gasErr
 	}
 
+	data, err := parseInputData(inputData)
+	if handlerForError(txLog, err) != nil {
+		return err
+	}
+
 	var gLimit uint64
 	if gasLimit == "" {
-		gLimit, err = core.IntrinsicGas([]byte(""), false, true, true, false)
+		gLimit, err = core.IntrinsicGas(data, false, true, true, false)
 		if handlerForError(txLog, err) != nil {
 			return err
 		}
@@ -103,7 +112,7 @@ func ethHandlerForTransaction(txLog *transactionLog) error {
 		nonce, gLimit,
 		toAddress.String(),
 		amt, gPrice,
-		[]byte{},
+		data,
 	)
 
 	if dryRun {
@@ -178,6 +187,11 @@ func ethHandlerForBulkTransactions(txLog *transactionLog, index int) error {
 	} else {
 		gasLimit = "" // Reset to default for subsequent transactions
 	}
+	if txnFlags.InputData != nil {
+		inputData = *txnFlags.InputData
+	} else {
+		inputData = "" // Reset to default for subsequent transactions
+	}
 	trueNonce = txnFlags.TrueNonce
 
 	return ethHandlerForTransaction(txLog)
@@ -187,11 +201,15 @@ func ethOpts(ctlr *transaction.EthController) {
 	if dryRun {
 		ctlr.Behavior.DryRun = true
 	}
+	if simulateTx {
+		ctlr.Behavior.Simulate = true
+	}
 	if useLedgerWallet {
 		ctlr.Behavior.SigningImpl = transaction.Ledger
 	}
 	if timeout > 0 {
 		ctlr.Behavior.ConfirmationWaitTime = timeout
+		ctlr.Behavior.Confirmations = confirmations
 	}
 }
 
@@ -273,9 +291,12 @@ Create an Ethereum compatible transaction, sign it, and send off to the intelcha
 	cmdEthTransfer.Flags().StringVar(&amount, "amount", "0", "amount to send (ITC)")
 	cmdEthTransfer.Flags().StringVar(&gasPrice, "gas-price", "100", "gas price to pay (TICKS)")
 	cmdEthTransfer.Flags().StringVar(&gasLimit, "gas-limit", "", "gas limit")
+	cmdEthTransfer.Flags().StringVar(&inputData, "data", "", "hex encoded input data for the transaction")
+	cmdEthTransfer.Flags().BoolVar(&simulateTx, "simulate", false, "run transactions with input data through a call first and refuse to send if they would fail")
 	cmdEthTransfer.Flags().StringVar(&inputNonce, "nonce", "", "set nonce for tx")
 	cmdEthTransfer.Flags().StringVar(&targetChain, "chain-id", "", "what chain ID to target")
 	cmdEthTransfer.Flags().Uint32Var(&timeout, "timeout", defaultTimeout, "set timeout in seconds. Set to 0 to not wait for confirm")
+	cmdEthTransfer.Flags().Uint32Var(&confirmations, "confirmations", 1, confirmationsUsage)
 	cmdEthTransfer.Flags().BoolVar(&userProvidesPassphrase, "passphrase", false, ppPrompt)
 	cmdEthTransfer.Flags().StringVar(&passphraseFilePath, "passphrase-file", "", "path to a file containing the passphrase")
 
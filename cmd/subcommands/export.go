@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+
+	"github.com/intelchain-itc/itc-sdk/pkg/history"
+	"github.com/intelchain-itc/itc-sdk/pkg/journal"
+	"github.com/intelchain-itc/itc-sdk/pkg/store"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportAddresses []string
+	exportSince     string
+	exportUntil     string
+	exportFormat    string
+	exportOut       string
+	exportAccounts  = journal.DefaultAccounts
+)
+
+// ownedAddresses reads addresses given as address or Label=address, or as
+// the name of an account in the keystore, into their account name labels
+func ownedAddresses(values []string) (map[string]string, error) {
+	owned := map[string]string{}
+	for _, value := range values {
+		label, account := "", value
+		if i := strings.Index(value, "="); i >= 0 {
+			label, account = value[:i], value[i+1:]
+		}
+		a := itcAddress{}
+		if err := a.Set(account); err != nil {
+			acc, nameErr := store.AddressFromAccountName(account)
+			if nameErr != nil {
+				return nil, errors.Wrapf(err, "%s is neither an address nor an account name", account)
+			}
+			a.address = acc
+		}
+		if label == "" {
+			label = journal.Label(a.address)
+		}
+		owned[a.address] = label
+	}
+	return owned, nil
+}
+
+func exportLedgerCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ledger",
+		Short: "journal entries of a set of addresses for beancount or ledger-cli",
+		Args:  cobra.ExactArgs(0),
+		Long: `
+Pull the transfers, cross-shard transfers, delegations, undelegations and
+reward collections of every --address on all shards between --since and
+--until, and write them as balanced journal entries in --format beancount or
+ledger to --out or standard output.
+
+Addresses are given as an address, an account name of the keystore or
+Label=address, where Label names the address in its accounts. Each address
+holds an asset account per shard and one for its stake. Gas fees are booked to
+--fees-account. Transfers between the given addresses are booked between
+their asset accounts and not counted as income or payments. Undelegated
+amounts are booked back to the shard 0 account when undelegated, the lockup
+until their release is not booked.
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(exportAddresses) == 0 {
+				return errors.New("--address is required")
+			}
+			if exportFormat != journal.Beancount && exportFormat != journal.Ledger {
+				return errors.Errorf("unknown format %s, expected %s or %s", exportFormat, journal.Beancount, journal.Ledger)
+			}
+			owned, err := ownedAddresses(exportAddresses)
+			if err != nil {
+				return err
+			}
+			book, err := journal.NewBook(exportAccounts, owned)
+			if err != nil {
+				return err
+			}
+			filter := history.Filter{}
+			if exportSince != "" {
+				if filter.Since, err = parseTime(exportSince); err != nil {
+					return err
+				}
+			}
+			if exportUntil != "" {
+				if filter.Until, err = parseTime(exportUntil); err != nil {
+					return err
+				}
+			}
+
+			txs := []history.Transaction{}
+			for address := range owned {
+				addressTxs, err := accountHistory(address, nil, true, filter, true)
+				if err != nil {
+					return errors.Wrapf(err, "could not fetch the history of %s", address)
+				}
+				txs = append(txs, addressTxs...)
+			}
+			entries := book.Entries(history.Filter{}.Apply(txs))
+
+			w := os.Stdout
+			if exportOut != "" {
+				if w, err = os.Create(exportOut); err != nil {
+					return err
+				}
+				defer w.Close()
+			}
+			return journal.Write(w, exportFormat, entries)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&exportAddresses, "address", []string{}, "addresses to book: address, account name or Label=address")
+	cmd.Flags().StringVar(&exportSince, "since", "", "only transactions at or after this date or time")
+	cmd.Flags().StringVar(&exportUntil, "until", "", "only transactions before this date or time")
+	cmd.Flags().StringVar(&exportFormat, "format", journal.Beancount, "journal format: beancount or ledger")
+	cmd.Flags().StringVar(&exportOut, "out", "", "file to write the journal to instead of standard output")
+	cmd.Flags().IntVar(&historyPageSize, "page-size", 100, "transactions to request per page")
+	cmd.Flags().StringVar(&exportAccounts.Assets, "assets-account", exportAccounts.Assets, "parent account of the holdings of each address")
+	cmd.Flags().StringVar(&exportAccounts.Received, "income-account", exportAccounts.Received, "account of transfers received from other addresses")
+	cmd.Flags().StringVar(&exportAccounts.Sent, "payments-account", exportAccounts.Sent, "account of transfers sent to other addresses")
+	cmd.Flags().StringVar(&exportAccounts.Fees, "fees-account", exportAccounts.Fees, "account of gas fees")
+	cmd.Flags().StringVar(&exportAccounts.Rewards, "rewards-account", exportAccounts.Rewards, "account of collected staking rewards")
+	return cmd
+}
+
+func init() {
+	cmdExport := &cobra.Command{
+		Use:   "export",
+		Short: "export account activity for accounting",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.Help()
+			return nil
+		},
+	}
+
+	cmdExport.AddCommand(exportLedgerCommand())
+	RootCmd.AddCommand(cmdExport)
+}
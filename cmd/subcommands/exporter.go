@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/intelchain-itc/itc-sdk/pkg/exporter"
+	"github.com/intelchain-itc/itc-sdk/pkg/sharding"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exporterListen     string
+	exporterAddresses  []string
+	exporterValidators []string
+	exporterInterval   time.Duration
+)
+
+func init() {
+	cmdExporter := &cobra.Command{
+		Use:   "exporter",
+		Short: "serve Prometheus metrics of addresses, validators and nodes",
+		Args:  cobra.ExactArgs(0),
+		Long: `
+Serve Prometheus metrics on --listen at /metrics: balances and nonces of each
+--address on every shard, their delegations and rewards, signing rate and
+election status of each --validator, and block heights of every shard with
+the sync lag of --node. The node is scraped every --scrape-interval with one
+batched request per endpoint, however often Prometheus scrapes the exporter.
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			for _, addr := range append(append([]string{}, exporterAddresses...), exporterValidators...) {
+				if err := new(itcAddress).Set(addr); err != nil {
+					return err
+				}
+			}
+			if exporterInterval <= 0 {
+				return errors.New("--scrape-interval must be positive")
+			}
+			routes, err := sharding.Structure(node)
+			if err != nil {
+				return err
+			}
+			shards := map[int]string{}
+			for _, route := range routes {
+				shards[route.ShardID] = route.HTTP
+			}
+			e := exporter.NewExporter(exporter.Config{
+				Node:       node,
+				Shards:     shards,
+				Addresses:  exporterAddresses,
+				Validators: exporterValidators,
+			}, exporterInterval)
+			go e.Run(make(chan struct{}))
+
+			mux := http.NewServeMux()
+			mux.Handle("/metrics", e)
+			fmt.Printf("Serving metrics of %d shards on %s/metrics\n", len(shards), exporterListen)
+			return http.ListenAndServe(exporterListen, mux)
+		},
+	}
+
+	cmdExporter.Flags().StringVar(&exporterListen, "listen", ":9109", "address to serve metrics on")
+	cmdExporter.Flags().StringSliceVar(&exporterAddresses, "address", []string{}, "addresses to report balances, nonces and delegations of")
+	cmdExporter.Flags().StringSliceVar(&exporterValidators, "validator", []string{}, "validators to report signing and election status of")
+	cmdExporter.Flags().DurationVar(&exporterInterval, "scrape-interval", 30*time.Second, "how often to query the node")
+	RootCmd.AddCommand(cmdExporter)
+}
@@ -43,6 +43,9 @@ func commandVote() (cmd *cobra.Command) {
 		Use:   "vote-proposal",
 		Short: "Vote on a proposal",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if !store.DoesNamedAccountExist(key) {
+				return fmt.Errorf("account %s doesn't exist", key)
+			}
 			keyStore := store.FromAccountName(key)
 			passphrase, err := getPassphrase()
 			if err != nil {
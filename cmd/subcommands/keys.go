@@ -8,9 +8,9 @@ import (
 	"os"
 	"strings"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
-	"github.com/tyler-smith/go-bip39"
 	"golang.org/x/crypto/ssh/terminal"
 
 	"github.com/intelchain-itc/itc-sdk/pkg/account"
@@ -28,15 +28,28 @@ const (
 )
 
 var (
-	quietImport            bool
-	recoverFromMnemonic    bool
-	userProvidesPassphrase bool
-	passphraseFilePath     string
-	passphrase             string
-	blsFilePath            string
-	blsShardID             uint32
-	blsCount               uint32
-	ppPrompt               = fmt.Sprintf(
+	quietImport                 bool
+	recoverFromMnemonic         bool
+	userProvidesPassphrase      bool
+	passphraseFilePath          string
+	passphrase                  string
+	blsFilePath                 string
+	blsShardID                  uint32
+	blsCount                    uint32
+	blsFromMnemonic             bool
+	blsMessageIsHash            bool
+	blsAggregateSignatures      []string
+	blsAggregatePublicKeys      []string
+	hdAccountNumber             uint32
+	hdIndexNumber               uint32
+	hdCount                     uint32
+	hdCoinType                  uint32
+	hdPath                      string
+	previewHdAccounts           bool
+	userProvidesBip39Passphrase bool
+	discoverAccounts            bool
+	discoveryGapLimit           uint32
+	ppPrompt                    = fmt.Sprintf(
 		"prompt for passphrase, otherwise use default passphrase: \"`%s`\"", c.DefaultPassphrase,
 	)
 )
@@ -104,6 +117,192 @@ func getPassphraseWithConfirm() (string, error) {
 	}
 }
 
+// hdAccountName names the account derived at index when several accounts are
+// created from one mnemonic, otherwise the chosen name is used as is
+func hdAccountName(name string, index uint32, several bool) string {
+	if several {
+		return fmt.Sprintf("%s-%d", name, index)
+	}
+	return name
+}
+
+// readMnemonic reads a mnemonic from stdin, checks it against the bip39
+// wordlists and its checksum and returns it normalized
+func readMnemonic() (string, error) {
+	fmt.Println("Enter mnemonic to recover keys from")
+	scanner := bufio.NewScanner(os.Stdin)
+	scanner.Scan()
+	m := mnemonic.Normalize(scanner.Text())
+	if _, err := mnemonic.Validate(m); err != nil {
+		return "", err
+	}
+	return m, nil
+}
+
+// getBip39Passphrase prompts for the optional passphrase extending the
+// mnemonic, also known as the 25th word
+func getBip39Passphrase() (string, error) {
+	if !userProvidesBip39Passphrase {
+		return "", nil
+	}
+	fmt.Println("Enter bip39 passphrase of the mnemonic:")
+	pass, err := terminal.ReadPassword(int(os.Stdin.Fd()))
+	if err != nil {
+		return "", err
+	}
+	return string(pass), nil
+}
+
+// deriveHdAccounts returns the keys of the mnemonic selected by the HD flags,
+// either an explicit path, a range of indices or the used ones found by discovery
+func deriveHdAccounts(cmd *cobra.Command, m, bip39Passphrase string) ([]account.Derived, error) {
+	if hdPath != "" {
+		for _, flag := range []string{"account", "index", "count", "coin-type", "discover"} {
+			if cmd.Flags().Changed(flag) {
+				return nil, fmt.Errorf("--hd-path can not be combined with --%s", flag)
+			}
+		}
+		addr, err := account.AddressFromPath(m, bip39Passphrase, hdPath)
+		if err != nil {
+			return nil, err
+		}
+		return []account.Derived{{Path: hdPath, Address: addr}}, nil
+	}
+	w := account.HDWallet{
+		Mnemonic:   m,
+		Passphrase: bip39Passphrase,
+		CoinType:   hdCoinType,
+		Account:    hdAccountNumber,
+	}
+	if !discoverAccounts {
+		if hdCount == 0 {
+			return nil, fmt.Errorf("count must be at least 1")
+		}
+		return account.DeriveRange(w, hdIndexNumber, hdCount)
+	}
+	used, err := account.ShardUsageCheck(node)
+	if err != nil {
+		return nil, err
+	}
+	derived, err := account.Discover(w, discoveryGapLimit, used)
+	if err != nil {
+		return nil, err
+	}
+	if len(derived) == 0 {
+		return nil, fmt.Errorf(
+			"no used address found in the first %d indices of account %d", discoveryGapLimit, hdAccountNumber,
+		)
+	}
+	return derived, nil
+}
+
+// createHdAccounts shows the address each selected path of the mnemonic
+// yields and, unless previewing, imports them as named accounts
+func createHdAccounts(cmd *cobra.Command, name, m string) ([]account.Derived, error) {
+	bip39Passphrase, err := getBip39Passphrase()
+	if err != nil {
+		return nil, err
+	}
+	derived, err := deriveHdAccounts(cmd, m, bip39Passphrase)
+	if err != nil {
+		return nil, err
+	}
+	several := discoverAccounts || len(derived) > 1
+	for _, d := range derived {
+		n := hdAccountName(name, d.Index, several)
+		fmt.Printf("%s\t%s\t%s\n", d.Path, d.Address, n)
+		if !previewHdAccounts && store.DoesNamedAccountExist(n) {
+			return nil, fmt.Errorf("account %s already exists", n)
+		}
+	}
+	if previewHdAccounts {
+		return derived, nil
+	}
+	passphrase, err := getPassphraseWithConfirm()
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range derived {
+		acc := account.Creation{
+			Name:            hdAccountName(name, d.Index, several),
+			Passphrase:      passphrase,
+			Mnemonic:        m,
+			HdPath:          d.Path,
+			Bip39Passphrase: bip39Passphrase,
+		}
+		if err := account.CreateNewLocalAccount(&acc); err != nil {
+			return nil, err
+		}
+	}
+	return derived, nil
+}
+
+// addHdFlags registers the flags selecting which keys of a mnemonic to import
+func addHdFlags(cmd *cobra.Command) {
+	cmd.Flags().Uint32Var(&hdCoinType, "coin-type", keys.IntelchainCoinType,
+		fmt.Sprintf("BIP44 coin type to derive keys under, %d for ethereum wallets", keys.EthereumCoinType))
+	cmd.Flags().Uint32Var(&hdAccountNumber, "account", 0, "HD account number to derive keys under")
+	cmd.Flags().Uint32Var(&hdIndexNumber, "index", 0, "HD index of the first key to derive")
+	cmd.Flags().Uint32Var(&hdCount, "count", 1, "how many consecutive keys to derive, named <ACCOUNT_NAME>-<INDEX>")
+	cmd.Flags().StringVar(&hdPath, "hd-path", "", "derive the single key at this BIP32 path, e.g. m/44'/60'/0'/0/0")
+	cmd.Flags().BoolVar(&userProvidesBip39Passphrase, "bip39-passphrase", false,
+		"prompt for the optional bip39 passphrase of the mnemonic")
+	cmd.Flags().BoolVar(&previewHdAccounts, "preview", false, "only show the address each path yields, import nothing")
+	cmd.Flags().BoolVar(&discoverAccounts, "discover", false,
+		"import the keys with a balance or nonce on any shard, named <ACCOUNT_NAME>-<INDEX>")
+	cmd.Flags().Uint32Var(&discoveryGapLimit, "gap-limit", account.DefaultGapLimit,
+		"consecutive unused indices after which discovery stops")
+}
+
+// blsMessage returns the bytes of a message to sign or verify, which is a
+// hex hash when --hash is given and plain text otherwise
+func blsMessage(message string) ([]byte, error) {
+	if !blsMessageIsHash {
+		return []byte(message), nil
+	}
+	msgHash, err := hexutil.Decode(message)
+	if err != nil {
+		return nil, fmt.Errorf("hash: %w", err)
+	}
+	return msgHash, nil
+}
+
+// connectBlsKeyNode falls back to mainnet when the node, whose shard count
+// decides which keys belong to a shard, can not be reached
+func connectBlsKeyNode() {
+	if err := validation.ValidateNodeConnection(node); err != nil {
+		fmt.Fprintf(os.Stderr, "Cannot connect to node %v, using intelchain mainnet endpoint %v\n",
+			node, defaultMainnetEndpoint)
+		node = defaultMainnetEndpoint
+	}
+}
+
+// blsKeysToGenerate asks for the file path and passphrase of each bls key to
+// generate or recover
+func blsKeysToGenerate() ([]*keys.BlsKey, error) {
+	blsKeys := []*keys.BlsKey{}
+
+	for i := uint32(0); i < blsCount; i++ {
+		keyFilePath := blsFilePath
+		if blsFilePath != "" {
+			fmt.Printf("Enter absolute path for key #%d:\n", i+1)
+			fmt.Scanln(&keyFilePath)
+		}
+
+		passphrase, err := getPassphraseWithConfirm()
+		if err != nil {
+			return nil, err
+		}
+
+		blsKey := &keys.BlsKey{
+			Passphrase: passphrase,
+			FilePath:   keyFilePath,
+		}
+		blsKeys = append(blsKeys, blsKey)
+	}
+	return blsKeys, nil
+}
+
 func keysSub() []*cobra.Command {
 	cmdList := &cobra.Command{
 		Use:   "list",
@@ -132,43 +331,34 @@ func keysSub() []*cobra.Command {
 		Short: "Create a new keystore key",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if store.DoesNamedAccountExist(args[0]) {
-				return fmt.Errorf("account %s already exists", args[0])
-			}
-			passphrase, err := getPassphraseWithConfirm()
-			if err != nil {
-				return err
+			if (discoverAccounts || previewHdAccounts) && !recoverFromMnemonic {
+				return fmt.Errorf("discovery and preview require an existing mnemonic, use --recover")
 			}
-			acc := account.Creation{
-				Name:       args[0],
-				Passphrase: passphrase,
+			if hdCount == 1 && !discoverAccounts && !previewHdAccounts && store.DoesNamedAccountExist(args[0]) {
+				return fmt.Errorf("account %s already exists", args[0])
 			}
+			m := mnemonic.Generate()
 			if recoverFromMnemonic {
 				fmt.Fprintf(os.Stderr, "deprecated method: use `./itc keys recover-from-mnemonic` instead.\n")
-				fmt.Println("Enter mnemonic to recover keys from")
-				scanner := bufio.NewScanner(os.Stdin)
-				scanner.Scan()
-				m := scanner.Text()
-				if !bip39.IsMnemonicValid(m) {
-					return mnemonic.InvalidMnemonic
+				var err error
+				if m, err = readMnemonic(); err != nil {
+					return err
 				}
-				acc.Mnemonic = m
 			}
-			if err := account.CreateNewLocalAccount(&acc); err != nil {
+			if _, err := createHdAccounts(cmd, args[0], m); err != nil {
 				return err
 			}
 			if !recoverFromMnemonic {
 				color.Red(seedPhraseWarning)
-				fmt.Println(acc.Mnemonic)
+				fmt.Println(m)
 			}
-			addr, _ := store.AddressFromAccountName(acc.Name)
-			fmt.Printf("ITC Address: %s\n", addr)
 			return nil
 		},
 	}
 	cmdAdd.Flags().BoolVar(&recoverFromMnemonic, "recover", false, "create keys from a mnemonic")
 	cmdAdd.Flags().BoolVar(&userProvidesPassphrase, "passphrase", false, ppPrompt)
 	cmdAdd.Flags().StringVar(&passphraseFilePath, "passphrase-file", "", "path to a file containing the passphrase")
+	addHdFlags(cmdAdd)
 
 	cmdRemove := &cobra.Command{
 		Use:   "remove <ACCOUNT_NAME>",
@@ -196,36 +386,26 @@ func keysSub() []*cobra.Command {
 		Short: "Recover account from mnemonic",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if store.DoesNamedAccountExist(args[0]) {
+			if hdCount == 1 && !discoverAccounts && !previewHdAccounts && store.DoesNamedAccountExist(args[0]) {
 				return fmt.Errorf("account %s already exists", args[0])
 			}
-			passphrase, err := getPassphraseWithConfirm()
+			m, err := readMnemonic()
 			if err != nil {
 				return err
 			}
-			acc := account.Creation{
-				Name:       args[0],
-				Passphrase: passphrase,
-			}
-			fmt.Println("Enter mnemonic to recover keys from")
-			scanner := bufio.NewScanner(os.Stdin)
-			scanner.Scan()
-			m := scanner.Text()
-			if !bip39.IsMnemonicValid(m) {
-				return mnemonic.InvalidMnemonic
-			}
-			acc.Mnemonic = m
-			if err := account.CreateNewLocalAccount(&acc); err != nil {
+			derived, err := createHdAccounts(cmd, args[0], m)
+			if err != nil {
 				return err
 			}
-			fmt.Println("Successfully recovered account from mnemonic!")
-			addr, _ := store.AddressFromAccountName(acc.Name)
-			fmt.Printf("ITC Address: %s\n", addr)
+			if !previewHdAccounts {
+				fmt.Printf("Successfully recovered %d account(s) from mnemonic!\n", len(derived))
+			}
 			return nil
 		},
 	}
 	cmdRecoverMnemonic.Flags().BoolVar(&userProvidesPassphrase, "passphrase", false, ppPrompt)
 	cmdRecoverMnemonic.Flags().StringVar(&passphraseFilePath, "passphrase-file", "", "path to a file containing the passphrase")
+	addHdFlags(cmdRecoverMnemonic)
 
 	cmdImportKS := &cobra.Command{
 		Use:   "import-ks <KEYSTORE_FILE_PATH> [ACCOUNT_NAME]",
@@ -340,33 +520,25 @@ func keysSub() []*cobra.Command {
 		Use:   "generate-bls-keys",
 		Short: "Generates multiple bls keys for a given shard network configuration and then encrypts and saves the private key with a requested passphrase",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if err := validation.ValidateNodeConnection(node); err != nil {
-				fmt.Fprintf(os.Stderr, "Cannot connect to node %v, using intelchain mainnet endpoint %v\n",
-					node, defaultMainnetEndpoint)
-				node = defaultMainnetEndpoint
-			}
-			blsKeys := []*keys.BlsKey{}
-
-			for i := uint32(0); i < blsCount; i++ {
-				keyFilePath := blsFilePath
-				if blsFilePath != "" {
-					fmt.Printf("Enter absolute path for key #%d:\n", i+1)
-					fmt.Scanln(&keyFilePath)
-				}
-
-				passphrase, err := getPassphraseWithConfirm()
-				if err != nil {
-					return err
-				}
-
-				blsKey := &keys.BlsKey{
-					Passphrase: passphrase,
-					FilePath:   keyFilePath,
-				}
-				blsKeys = append(blsKeys, blsKey)
+			connectBlsKeyNode()
+			blsKeys, err := blsKeysToGenerate()
+			if err != nil {
+				return err
 			}
-
-			return keys.GenMultiBlsKeys(blsKeys, node, blsShardID)
+			if !blsFromMnemonic {
+				return keys.GenMultiBlsKeys(blsKeys, node, blsShardID)
+			}
+			m := mnemonic.Generate()
+			bip39Passphrase, err := getBip39Passphrase()
+			if err != nil {
+				return err
+			}
+			if err := keys.GenMnemonicBlsKeys(blsKeys, m, bip39Passphrase, node, blsShardID); err != nil {
+				return err
+			}
+			color.Red(seedPhraseWarning)
+			fmt.Println(m)
+			return nil
 		},
 	}
 	cmdGenerateMultiBlsKeys.Flags().StringVar(&blsFilePath, "bls-file-path", "",
@@ -375,10 +547,47 @@ func keysSub() []*cobra.Command {
 	cmdGenerateMultiBlsKeys.Flags().StringVar(&passphraseFilePath, "passphrase-file", "", "path to a file containing the passphrase")
 	cmdGenerateMultiBlsKeys.Flags().Uint32Var(&blsShardID, "shard", 0, "which shard to create bls keys for")
 	cmdGenerateMultiBlsKeys.Flags().Uint32Var(&blsCount, "count", 1, "how many bls keys to generate")
+	cmdGenerateMultiBlsKeys.Flags().BoolVar(&blsFromMnemonic, "mnemonic", false,
+		"derive the keys from a new mnemonic, which can recover them with recover-bls-keys")
+	cmdGenerateMultiBlsKeys.Flags().BoolVar(&userProvidesBip39Passphrase, "bip39-passphrase", false,
+		"prompt for the optional bip39 passphrase of the mnemonic")
+
+	cmdRecoverMultiBlsKeys := &cobra.Command{
+		Use:   "recover-bls-keys",
+		Short: "Re-derive the bls keys of a shard from the mnemonic they were generated with, then encrypt and save them",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !blsFromMnemonic {
+				return fmt.Errorf("bls keys can only be recovered from a mnemonic, use --mnemonic")
+			}
+			connectBlsKeyNode()
+			m, err := readMnemonic()
+			if err != nil {
+				return err
+			}
+			bip39Passphrase, err := getBip39Passphrase()
+			if err != nil {
+				return err
+			}
+			blsKeys, err := blsKeysToGenerate()
+			if err != nil {
+				return err
+			}
+			return keys.GenMnemonicBlsKeys(blsKeys, m, bip39Passphrase, node, blsShardID)
+		},
+	}
+	cmdRecoverMultiBlsKeys.Flags().StringVar(&blsFilePath, "bls-file-path", "",
+		"absolute path of where to save encrypted bls private keys")
+	cmdRecoverMultiBlsKeys.Flags().BoolVar(&userProvidesPassphrase, "passphrase", false, ppPrompt)
+	cmdRecoverMultiBlsKeys.Flags().StringVar(&passphraseFilePath, "passphrase-file", "", "path to a file containing the passphrase")
+	cmdRecoverMultiBlsKeys.Flags().Uint32Var(&blsShardID, "shard", 0, "which shard the bls keys were created for")
+	cmdRecoverMultiBlsKeys.Flags().Uint32Var(&blsCount, "count", 1, "how many bls keys to recover")
+	cmdRecoverMultiBlsKeys.Flags().BoolVar(&blsFromMnemonic, "mnemonic", false, "read the mnemonic to derive the keys from")
+	cmdRecoverMultiBlsKeys.Flags().BoolVar(&userProvidesBip39Passphrase, "bip39-passphrase", false,
+		"prompt for the optional bip39 passphrase of the mnemonic")
 
 	cmdRecoverBlsKey := &cobra.Command{
 		Use:   "recover-bls-key <ABSOLUTE_PATH_BLS_KEY>",
-		Short: "Recover bls keys from an encrypted bls key file",
+		Short: "Recover bls keys from an encrypted bls key file, in the current or the legacy format",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			passphrase, err := getPassphrase()
@@ -391,6 +600,107 @@ func keysSub() []*cobra.Command {
 	cmdRecoverBlsKey.Flags().BoolVar(&userProvidesPassphrase, "passphrase", false, ppPrompt)
 	cmdRecoverBlsKey.Flags().StringVar(&passphraseFilePath, "passphrase-file", "", "path to a file containing the passphrase")
 
+	cmdMigrateBlsKey := &cobra.Command{
+		Use:   "migrate-bls-key <ABSOLUTE_PATH_BLS_KEY>",
+		Short: "Re-encrypt a legacy bls key file in place with the current format, keeping a backup",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			passphrase, err := getPassphrase()
+			if err != nil {
+				return err
+			}
+			backupPath, err := keys.MigrateBlsKeyFile(passphrase, args[0])
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Migrated bls key file %s, the legacy file was kept at %s\n", args[0], backupPath)
+			return nil
+		},
+	}
+	cmdMigrateBlsKey.Flags().BoolVar(&userProvidesPassphrase, "passphrase", false, ppPrompt)
+	cmdMigrateBlsKey.Flags().StringVar(&passphraseFilePath, "passphrase-file", "", "path to a file containing the passphrase")
+
+	cmdBlsSign := &cobra.Command{
+		Use:   "bls-sign <ABSOLUTE_PATH_BLS_KEY> <MESSAGE>",
+		Short: "Sign a message, or a 32 byte hex hash with --hash, with a bls key file",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			message, err := blsMessage(args[1])
+			if err != nil {
+				return err
+			}
+			keyFile, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			passphrase, err := getPassphrase()
+			if err != nil {
+				return err
+			}
+			signature, publicKey, err := keys.BlsSign(keyFile, passphrase, message, blsMessageIsHash)
+			if err != nil {
+				return err
+			}
+			msgHash, _ := keys.BlsMessageHash(message, blsMessageIsHash)
+			fmt.Println(c.JSONPrettyFormat(fmt.Sprintf(
+				`{"public-key" : "0x%s", "message-hash" : "0x%x", "signature" : "0x%s"}`, publicKey, msgHash, signature,
+			)))
+			return nil
+		},
+	}
+	cmdBlsSign.Flags().BoolVar(&blsMessageIsHash, "hash", false, "the message is a 0x prefixed 32 byte hash to sign as is")
+	cmdBlsSign.Flags().BoolVar(&userProvidesPassphrase, "passphrase", false, ppPrompt)
+	cmdBlsSign.Flags().StringVar(&passphraseFilePath, "passphrase-file", "", "path to a file containing the passphrase")
+
+	cmdBlsVerify := &cobra.Command{
+		Use:   "bls-verify <PUBLIC_BLS_KEY> <SIGNATURE> <MESSAGE>",
+		Short: "Verify a bls signature, possibly aggregated, of a message against a public bls key",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			message, err := blsMessage(args[2])
+			if err != nil {
+				return err
+			}
+			if err := keys.BlsVerify(args[0], args[1], message, blsMessageIsHash); err != nil {
+				return err
+			}
+			fmt.Println("bls signature is valid")
+			return nil
+		},
+	}
+	cmdBlsVerify.Flags().BoolVar(&blsMessageIsHash, "hash", false, "the message is a 0x prefixed 32 byte hash that was signed as is")
+
+	cmdBlsAggregate := &cobra.Command{
+		Use:   "bls-aggregate",
+		Short: "Aggregate bls signatures of the same message, and the public bls keys they verify against",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(blsAggregateSignatures) == 0 && len(blsAggregatePublicKeys) == 0 {
+				return fmt.Errorf("nothing to aggregate, use --signatures and/or --public-keys")
+			}
+			fields := []string{}
+			if len(blsAggregateSignatures) > 0 {
+				signature, err := keys.BlsAggregateSignatures(blsAggregateSignatures)
+				if err != nil {
+					return err
+				}
+				fields = append(fields, fmt.Sprintf(`"signature" : "0x%s"`, signature))
+			}
+			if len(blsAggregatePublicKeys) > 0 {
+				publicKey, err := keys.BlsAggregatePublicKeys(blsAggregatePublicKeys)
+				if err != nil {
+					return err
+				}
+				fields = append(fields, fmt.Sprintf(`"public-key" : "0x%s"`, publicKey))
+			}
+			fmt.Println(c.JSONPrettyFormat(fmt.Sprintf("{%s}", strings.Join(fields, ","))))
+			return nil
+		},
+	}
+	cmdBlsAggregate.Flags().StringSliceVar(&blsAggregateSignatures, "signatures", []string{},
+		"bls signatures of the same message to aggregate")
+	cmdBlsAggregate.Flags().StringSliceVar(&blsAggregatePublicKeys, "public-keys", []string{},
+		"public bls keys to aggregate")
+
 	cmdSaveBlsKey := &cobra.Command{
 		Use:   "save-bls-key <PRIVATE_BLS_KEY>",
 		Short: "Encrypt and save the bls private key with a requested passphrase",
@@ -443,7 +753,8 @@ func keysSub() []*cobra.Command {
 
 	return []*cobra.Command{cmdList, cmdLocation, cmdAdd, cmdRemove, cmdMnemonic, cmdRecoverMnemonic,
 		cmdImportKS, cmdImportPK, cmdExportKS, cmdExportPK, cmdCheckPassphrase,
-		cmdGenerateBlsKey, cmdGenerateMultiBlsKeys, cmdRecoverBlsKey, cmdSaveBlsKey, GetPublicBlsKey}
+		cmdGenerateBlsKey, cmdGenerateMultiBlsKeys, cmdRecoverMultiBlsKeys, cmdRecoverBlsKey, cmdMigrateBlsKey,
+		cmdSaveBlsKey, GetPublicBlsKey, cmdBlsSign, cmdBlsVerify, cmdBlsAggregate}
 }
 
 func init() {
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/intelchain-itc/itc-sdk/pkg/common"
+	"github.com/intelchain-itc/itc-sdk/pkg/output"
+	"github.com/intelchain-itc/itc-sdk/pkg/rpc"
+	"github.com/spf13/cobra"
+)
+
+var (
+	outputFormat string
+
+	balanceView = output.View{Columns: []output.Column{
+		{Header: "shard", Path: "shard"},
+		{Header: "amount", Path: "amount"},
+	}}
+	addressView = output.View{Columns: []output.Column{
+		{Header: "address", Path: ""},
+	}}
+	validatorView = output.View{Columns: []output.Column{
+		{Header: "address", Path: "validator.address"},
+		{Header: "name", Path: "validator.name"},
+		{Header: "rate", Path: "validator.rate"},
+		{Header: "total-delegation", Path: "total-delegation", Amount: true},
+		{Header: "max-total-delegation", Path: "validator.max-total-delegation", Amount: true},
+		{Header: "epos-status", Path: "epos-status"},
+		{Header: "active-status", Path: "active-status"},
+		{Header: "in-committee", Path: "currently-in-committee"},
+		{Header: "signed", Path: "current-epoch-performance.current-epoch-signing-percent.current-epoch-signed"},
+		{Header: "to-sign", Path: "current-epoch-performance.current-epoch-signing-percent.current-epoch-to-sign"},
+	}}
+	delegationView = output.View{Columns: []output.Column{
+		{Header: "validator", Path: "validator_address"},
+		{Header: "delegator", Path: "delegator_address"},
+		{Header: "amount", Path: "amount", Amount: true},
+		{Header: "reward", Path: "reward", Amount: true},
+		{Header: "undelegations", Path: "Undelegations"},
+	}}
+	transactionColumns = []output.Column{
+		{Header: "hash", Path: "hash"},
+		{Header: "block", Path: "blockNumber"},
+		{Header: "timestamp", Path: "timestamp"},
+		{Header: "from", Path: "from"},
+		{Header: "to", Path: "to"},
+		{Header: "value", Path: "value", Amount: true},
+		{Header: "shard", Path: "shardID"},
+		{Header: "to-shard", Path: "toShardID"},
+		{Header: "gas", Path: "gas"},
+		{Header: "gas-price", Path: "gasPrice"},
+		{Header: "nonce", Path: "nonce"},
+	}
+	committeeView = output.View{
+		Rows: "current.quorum-deciders.*.committee-members",
+		Key:  "shard",
+		Columns: []output.Column{
+			{Header: "shard", Path: "shard"},
+			{Header: "bls-public-key", Path: "bls-public-key"},
+			{Header: "earning-account", Path: "earning-account"},
+			{Header: "voting-power", Path: "voting-power-%"},
+			{Header: "effective-stake", Path: "effective-stake", Amount: true},
+		},
+	}
+	doctorView = output.View{
+		Rows: "endpoints",
+		Columns: []output.Column{
+			{Header: "name", Path: "name"},
+			{Header: "url", Path: "url"},
+			{Header: "shard", Path: "shard-id"},
+			{Header: "reachable", Path: "reachable"},
+			{Header: "latency-ms", Path: "latency-ms"},
+			{Header: "height", Path: "height"},
+			{Header: "height-lag", Path: "height-lag"},
+			{Header: "block-time", Path: "block-time"},
+			{Header: "syncing", Path: "syncing"},
+			{Header: "peers", Path: "peers"},
+			{Header: "chain-id", Path: "chain-id"},
+			{Header: "error", Path: "error"},
+		},
+	}
+	pendingUndelegationsView = output.View{
+		Rows: "undelegations",
+		Columns: []output.Column{
+			{Header: "validator", Path: "validator"},
+			{Header: "amount", Path: "amount"},
+			{Header: "undelegated-epoch", Path: "undelegated-epoch"},
+			{Header: "release-epoch", Path: "release-epoch"},
+			{Header: "epochs-left", Path: "epochs-left"},
+			{Header: "estimated-release", Path: "estimated-release"},
+			{Header: "spendable", Path: "spendable"},
+		},
+	}
+)
+
+// viewOf is how the result of an RPC method lays out as rows, methods
+// without one have every field flattened into a column
+func viewOf(method string) output.View {
+	switch method {
+	case rpc.Method.GetElectedValidatorAddresses, rpc.Method.GetAllValidatorAddresses:
+		return addressView
+	case rpc.Method.GetValidatorInformation, rpc.Method.GetValidatorInformationByBlockNumber,
+		rpc.Method.GetAllValidatorInformation, rpc.Method.GetAllValidatorInformationByBlockNumber:
+		return validatorView
+	case rpc.Method.GetDelegationsByDelegator, rpc.Method.GetDelegationsByValidator:
+		return delegationView
+	case rpc.Method.GetTransactionsHistory:
+		return output.View{Rows: "transactions", Columns: transactionColumns}
+	case rpc.Method.GetPendingTxnsInPool:
+		return output.View{Columns: transactionColumns}
+	case rpc.Method.GetSuperCommmittees:
+		return committeeView
+	}
+	return output.View{}
+}
+
+// headerView lays out rows of records keyed by header, in its order
+func headerView(header []string) output.View {
+	columns := []output.Column{}
+	for _, field := range header {
+		columns = append(columns, output.Column{Header: field, Path: field})
+	}
+	return output.View{Columns: columns}
+}
+
+// outputChanged reports whether --output was given. Commands with a report
+// of their own print it unless a format is asked for.
+func outputChanged(cmd *cobra.Command) bool {
+	return cmd.Flags().Changed("output")
+}
+
+// printValue prints v as JSON in --output
+func printValue(v interface{}, view output.View) error {
+	return printResult(common.ToJSONUnsafe(v, !noPrettyOutput), view)
+}
+
+// printResult prints a JSON result in --output, JSON as it is
+func printResult(result string, view output.View) error {
+	if outputFormat == output.JSON {
+		os.Stdout.WriteString(result + "\n")
+		return nil
+	}
+	return output.Write(os.Stdout, outputFormat, json.RawMessage(result), view)
+}
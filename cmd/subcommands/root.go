@@ -7,13 +7,16 @@ import (
 	"net/http"
 	"os"
 	"path"
+	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/intelchain-itc/itc-sdk/pkg/address"
 
 	color "github.com/fatih/color"
 	"github.com/intelchain-itc/itc-sdk/pkg/common"
+	"github.com/intelchain-itc/itc-sdk/pkg/output"
 	"github.com/intelchain-itc/itc-sdk/pkg/rpc"
 	rpcEth "github.com/intelchain-itc/itc-sdk/pkg/rpc/eth"
 	rpcV1 "github.com/intelchain-itc/itc-sdk/pkg/rpc/v1"
@@ -32,12 +35,20 @@ var (
 	node            string
 	rpcPrefix       string
 	keyStoreDir     string
+	keyStoreRO      bool
 	givenFilePath   string
 	endpoint        = regexp.MustCompile(`https://testnet\.s[0-9]\..*\.intelchain\.network`)
 	request         = func(method string, params []interface{}) error {
 		if !noLatest {
 			params = append(params, "latest")
 		}
+		if outputFormat != output.JSON {
+			result := json.RawMessage{}
+			if err := rpc.RequestResult(method, node, params, &result); err != nil {
+				return err
+			}
+			return output.Write(os.Stdout, outputFormat, result, viewOf(method))
+		}
 		success, failure := rpc.Request(method, node, params)
 		if failure != nil {
 			return failure
@@ -59,6 +70,15 @@ var (
 			if verbose {
 				common.EnableAllVerbose()
 			}
+			if !output.Valid(outputFormat) {
+				return errors.Errorf("--output must be one of %s", strings.Join(output.Formats, ", "))
+			}
+			if err := selectKeyStore(); err != nil {
+				return err
+			}
+			if err := applyConfig(cmd); err != nil {
+				return err
+			}
 			switch rpcPrefix {
 			case "itc":
 				rpc.Method = rpcV1.Method
@@ -84,7 +104,9 @@ var (
 			}
 
 			if targetChain == "" {
-				if node == defaultNodeAddr {
+				if chain, ok := customChainServing(node); ok {
+					chainName = chainIDWrapper{chainID: chain.ID()}
+				} else if node == defaultNodeAddr {
 					routes, err := sharding.Structure(node)
 					if err != nil {
 						chainName = chainIDWrapper{chainID: &common.Chain.TestNet}
@@ -107,6 +129,12 @@ var (
 					return err
 				}
 				chainName = chainIDWrapper{chainID: chain}
+				if custom, ok := common.LookupCustomChain(chain.Name); ok && node == defaultNodeAddr {
+					if node0, ok := custom.Endpoints[0]; ok {
+						node = node0
+					}
+					sharding.SetShardEndpoints(custom.Endpoints)
+				}
 			}
 
 			return nil
@@ -127,12 +155,16 @@ func init() {
 	RootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, vS)
 	RootCmd.PersistentFlags().StringVarP(&node, "node", "n", defaultNodeAddr, "<host>")
 	RootCmd.PersistentFlags().StringVarP(&rpcPrefix, "rpc-prefix", "r", defaultRpcPrefix, "<rpc>")
+	RootCmd.PersistentFlags().StringVar(&profileName, "profile", "",
+		"profile of ~/.itc_cli/config.yaml to use (default is its default-profile)")
 	RootCmd.PersistentFlags().BoolVar(
 		&noLatest, "no-latest", false, "Do not add 'latest' to RPC params",
 	)
 	RootCmd.PersistentFlags().BoolVar(
 		&noPrettyOutput, "no-pretty", false, "Disable pretty print JSON outputs",
 	)
+	RootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", output.JSON,
+		"output format of query results: json, yaml, table or csv")
 	RootCmd.AddCommand(&cobra.Command{
 		Use:   "cookbook",
 		Short: "Example usages of the most important, frequently used commands",
@@ -158,6 +190,10 @@ func init() {
 			return nil
 		},
 	})
+	RootCmd.PersistentFlags().StringVar(&keyStoreDir, "keystore-dir", "",
+		"directory of the local accounts, same as env var ITC_KEYSTORE_DIR (default ~/.itc_cli/account-keys)")
+	RootCmd.PersistentFlags().BoolVar(&keyStoreRO, "keystore-readonly", false,
+		"never create, import or remove local accounts, same as env var ITC_KEYSTORE_READONLY")
 	RootCmd.PersistentFlags().BoolVarP(&useLedgerWallet, "ledger", "e", false, "Use ledger hardware wallet")
 	RootCmd.PersistentFlags().StringVar(&givenFilePath, "file", "", "Path to file for given command when applicable")
 	RootCmd.AddCommand(&cobra.Command{
@@ -173,6 +209,35 @@ func init() {
 	})
 }
 
+// selectKeyStore picks the backend of the local accounts from the flags, or
+// else from the ITC_KEYSTORE_DIR and ITC_KEYSTORE_READONLY env vars
+func selectKeyStore() error {
+	dir := keyStoreDir
+	if dir == "" {
+		dir = os.Getenv("ITC_KEYSTORE_DIR")
+	}
+	if value, set := os.LookupEnv("ITC_KEYSTORE_READONLY"); set && !keyStoreRO {
+		readOnly, err := strconv.ParseBool(value)
+		if err != nil {
+			return errors.Errorf("ITC_KEYSTORE_READONLY=%s is not a boolean", value)
+		}
+		keyStoreRO = readOnly
+	}
+	backend := store.CurrentBackend()
+	if dir != "" {
+		absDir, err := filepath.Abs(dir)
+		if err != nil {
+			return err
+		}
+		backend = store.NewDirBackend(absDir)
+	}
+	if keyStoreRO {
+		backend = store.ReadOnly(backend)
+	}
+	store.SetBackend(backend)
+	return nil
+}
+
 var (
 	// VersionWrapDump meant to be set from main.go
 	VersionWrapDump = ""
@@ -0,0 +1,227 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"strings"
+
+	staking "github.com/intelchain-itc/intelchain/staking/types"
+	"github.com/intelchain-itc/itc-sdk/pkg/common"
+	"github.com/intelchain-itc/itc-sdk/pkg/delegation"
+	"github.com/intelchain-itc/itc-sdk/pkg/rpc"
+	"github.com/intelchain-itc/itc-sdk/pkg/sharding"
+	"github.com/intelchain-itc/itc-sdk/pkg/transaction"
+	"github.com/intelchain-itc/itc-sdk/pkg/validator"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	stakingBatchPath string
+	stakingSplit     []string
+)
+
+// batchPayload builds the staking message of one batch entry, amount is the
+// entry's amount in atto
+type batchPayload func(entry delegation.Entry, amount *big.Int) (staking.Directive, interface{})
+
+// batchResult is the outcome of one transaction of a batch
+type batchResult struct {
+	delegation.Entry
+	Nonce  uint64 `json:"nonce"`
+	TxHash string `json:"transaction-hash,omitempty"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// isStakingBatch reports whether a delegate, undelegate or collect-rewards
+// command was given a batch or split instead of a single transaction
+func isStakingBatch() bool {
+	return stakingBatchPath != "" || len(stakingSplit) > 0
+}
+
+// stakingBatchEntries reads the batch file, or splits --amount of
+// --delegator-addr across the validators of --split
+func stakingBatchEntries(cmd *cobra.Command) ([]delegation.Entry, error) {
+	if cmd.Flags().Changed("nonce") {
+		return nil, errors.New("--nonce can not be used with a batch, nonces are assigned per delegator")
+	}
+	if stakingBatchPath != "" {
+		if len(stakingSplit) > 0 {
+			return nil, errors.New("--batch and --split can not be combined")
+		}
+		return delegation.LoadBatch(stakingBatchPath)
+	}
+	if err := requireFlags(cmd, []string{"delegator-addr", "amount"}); err != nil {
+		return nil, err
+	}
+	if cmd.Flags().Changed("validator-addr") {
+		return nil, errors.New("--validator-addr can not be combined with --split, which names the validators")
+	}
+	shares, err := delegation.ParseSplit(stakingSplit)
+	if err != nil {
+		return nil, err
+	}
+	return delegation.Split(delegatorAddress.String(), stakingAmount, shares)
+}
+
+// checkBatchEntries validates the addresses and amounts of a batch before
+// anything is signed
+func checkBatchEntries(entries []delegation.Entry, withValidator bool) error {
+	for i, entry := range entries {
+		addresses := []string{entry.Delegator}
+		if withValidator {
+			addresses = append(addresses, entry.Validator)
+			if _, err := entry.Atto(); err != nil {
+				return errors.Wrapf(err, "batch entry #%d", i+1)
+			}
+		}
+		for _, addr := range addresses {
+			if err := new(itcAddress).Set(addr); err != nil {
+				return errors.Wrapf(err, "batch entry #%d", i+1)
+			}
+		}
+	}
+	return nil
+}
+
+// checkBatchCapacity fetches every validator of a batch of delegations and
+// makes sure none would go over its max-total-delegation
+func checkBatchCapacity(entries []delegation.Entry) error {
+	routes, err := sharding.Structure(node)
+	if err != nil {
+		return err
+	}
+	beaconNode := beaconChainNode(routes)
+	infos := map[string]*validator.Information{}
+	for _, entry := range entries {
+		if _, ok := infos[entry.Validator]; ok {
+			continue
+		}
+		info, err := validator.GetInformation(beaconNode, entry.Validator)
+		if err != nil {
+			return errors.Wrapf(err, "validator address not found: %s", entry.Validator)
+		}
+		infos[entry.Validator] = info
+	}
+	return delegation.CheckCapacity(entries, infos)
+}
+
+// batchPassphrase is the keystore passphrase of an entry, read from its own
+// passphrase file when it names one
+func batchPassphrase(entry delegation.Entry, fallback string) (string, error) {
+	if entry.PassphraseFile == "" {
+		return fallback, nil
+	}
+	data, err := ioutil.ReadFile(entry.PassphraseFile)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// runStakingBatch sends one staking transaction per entry with consecutive
+// nonces per delegator, then waits for them unless the timeout is 0 and
+// prints the result of every transaction. Once a transaction of a delegator
+// can not be sent, the later ones of that delegator are skipped as their
+// nonces could never be used. Entries without a passphrase file of their own
+// use defaultPassphrase.
+func runStakingBatch(entries []delegation.Entry, defaultPassphrase string, payload batchPayload) error {
+	networkHandler, err := handlerForShard(0, node)
+	if err != nil {
+		return err
+	}
+	nonces, err := delegation.AssignNonces(entries, func(delegator string) (uint64, error) {
+		return getNonce(delegator, networkHandler)
+	})
+	if err != nil {
+		return err
+	}
+
+	results := make([]batchResult, len(entries))
+	failed := map[string]bool{}
+	for i, entry := range entries {
+		results[i] = batchResult{Entry: entry, Nonce: nonces[i]}
+		if failed[entry.Delegator] {
+			results[i].Status = "skipped"
+			results[i].Error = "an earlier transaction of the delegator was not sent"
+			continue
+		}
+		txHash, err := sendBatchEntry(entry, nonces[i], payload, networkHandler, defaultPassphrase)
+		if err != nil {
+			failed[entry.Delegator] = true
+			results[i].Status = "failed"
+			results[i].Error = err.Error()
+			continue
+		}
+		results[i].TxHash = txHash
+		results[i].Status = "sent"
+	}
+
+	if timeout > 0 {
+		for i := range results {
+			if results[i].Status != "sent" {
+				continue
+			}
+			_, txErrors, err := transaction.WaitForConfirmation(
+				networkHandler, results[i].TxHash, transaction.NewConfirmationOptions(timeout, confirmations),
+			)
+			if err != nil {
+				results[i].Status = "failed"
+				results[i].Error = err.Error()
+				if len(txErrors) > 0 {
+					results[i].Error = txErrors[0].Error().Error()
+				}
+				continue
+			}
+			results[i].Status = "confirmed"
+		}
+	}
+
+	fmt.Println(common.ToJSONUnsafe(results, !noPrettyOutput))
+	for _, result := range results {
+		if result.Status == "failed" || result.Status == "skipped" {
+			return errors.New("not every transaction of the batch went through")
+		}
+	}
+	return nil
+}
+
+func sendBatchEntry(
+	entry delegation.Entry, nonce uint64, payload batchPayload,
+	networkHandler *rpc.HTTPMessenger, defaultPassphrase string,
+) (string, error) {
+	amount := new(big.Int)
+	if entry.Amount != "" {
+		var err error
+		if amount, err = entry.Atto(); err != nil {
+			return "", err
+		}
+	}
+	stakingTx, err := createStakingTransaction(nonce, func() (staking.Directive, interface{}) {
+		return payload(entry, amount)
+	})
+	if err != nil {
+		return "", err
+	}
+	passphrase, err = batchPassphrase(entry, defaultPassphrase)
+	if err != nil {
+		return "", err
+	}
+	return sendStakingTransaction(stakingTx, networkHandler, itcAddress{entry.Delegator})
+}
+
+// addBatchFlags adds the flags sending a batch, split only applies to
+// commands moving an amount
+func addBatchFlags(cmd *cobra.Command, withSplit bool) {
+	cmd.Flags().StringVar(
+		&stakingBatchPath, "batch", "", "YAML or JSON list of transactions with delegator, validator, amount and passphrase-file",
+	)
+	if withSplit {
+		cmd.Flags().StringSliceVar(
+			&stakingSplit, "split", []string{},
+			"split --amount across validators by weight, as <validator>=<weight>,<validator>=<weight>",
+		)
+	}
+}
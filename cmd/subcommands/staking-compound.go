@@ -0,0 +1,315 @@
+package cmd
+
+import (
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"time"
+
+	staking "github.com/intelchain-itc/intelchain/staking/types"
+	"github.com/intelchain-itc/itc-sdk/pkg/address"
+	"github.com/intelchain-itc/itc-sdk/pkg/common"
+	"github.com/intelchain-itc/itc-sdk/pkg/delegation"
+	"github.com/intelchain-itc/itc-sdk/pkg/rpc"
+	"github.com/intelchain-itc/itc-sdk/pkg/sharding"
+	"github.com/intelchain-itc/itc-sdk/pkg/transaction"
+	homedir "github.com/mitchellh/go-homedir"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	compoundThreshold     string
+	compoundInterval      time.Duration
+	compoundGasReserve    string
+	compoundMinDelegation string
+	compoundStateFile     string
+	compoundDryRun        bool
+	compoundOnce          bool
+)
+
+// compoundStatePath is where the progress of auto-compounding a delegator is
+// kept unless --state-file is given
+func compoundStatePath(delegatorAddr string) string {
+	uDir, _ := homedir.Dir()
+	return filepath.Join(uDir, common.DefaultConfigDirName, "compound", delegatorAddr+".json")
+}
+
+// compounder runs the rounds of auto-compound for one delegator
+type compounder struct {
+	beaconNode    string
+	statePath     string
+	target        string
+	threshold     *big.Int
+	gasReserve    *big.Int
+	minDelegation *big.Int
+	passphrase    string
+}
+
+// round collects the rewards once they pass the threshold and delegates what
+// was collected. Every transaction is saved to the state before it is waited
+// for, so a restart waits for it again instead of sending it twice.
+func (c *compounder) round() error {
+	delegator := delegatorAddress.String()
+	state, err := delegation.LoadCompoundState(c.statePath, delegator)
+	if err != nil {
+		return err
+	}
+	delegations, err := delegation.ByDelegator(c.beaconNode, delegator)
+	if err != nil {
+		return err
+	}
+	state.LastCheck = time.Now().UTC()
+	if compoundDryRun && (state.Stage == delegation.CompoundCollecting || state.Stage == delegation.CompoundDelegating) {
+		fmt.Println(common.ToJSONUnsafe(state, !noPrettyOutput))
+		return nil
+	}
+	networkHandler, err := handlerForShard(0, node)
+	if err != nil {
+		return err
+	}
+
+	if state.Stage == delegation.CompoundIdle {
+		rewards := delegation.Rewards(delegations)
+		if rewards.Cmp(c.threshold) < 0 {
+			fmt.Printf(
+				"Rewards of %s are %s ITC, below the %s ITC threshold\n",
+				delegator, common.FormatITC(rewards), compoundThreshold,
+			)
+			if compoundDryRun {
+				return nil
+			}
+			return state.Save(c.statePath)
+		}
+		if compoundDryRun {
+			fmt.Println(common.ToJSONUnsafe(map[string]interface{}{
+				"collect":  common.FormatITC(rewards),
+				"delegate": delegation.CompoundEntries(delegator, rewards, delegations, c.target, c.minDelegation),
+			}, !noPrettyOutput))
+			return nil
+		}
+		txHash, err := c.send(networkHandler, func() (staking.Directive, interface{}) {
+			return staking.DirectiveCollectRewards, staking.CollectRewards{
+				address.Parse(delegator),
+			}
+		})
+		if err != nil {
+			return err
+		}
+		state.Stage, state.Collected, state.CollectTx = delegation.CompoundCollecting, common.FormatITC(rewards), txHash
+		if err := state.Save(c.statePath); err != nil {
+			return err
+		}
+	}
+
+	if state.Stage == delegation.CompoundCollecting {
+		if resend, err := c.wait(networkHandler, state.CollectTx); err != nil {
+			if resend {
+				state.Reset()
+				if saveErr := state.Save(c.statePath); saveErr != nil {
+					return saveErr
+				}
+			}
+			return errors.Wrap(err, "rewards collection not confirmed")
+		}
+		state.Stage = delegation.CompoundCollected
+		if err := state.Save(c.statePath); err != nil {
+			return err
+		}
+	}
+
+	if state.Stage == delegation.CompoundCollected {
+		collected, err := delegation.ParseITC(state.Collected)
+		if err != nil {
+			return err
+		}
+		balance, err := delegation.Balance(c.beaconNode, delegator)
+		if err != nil {
+			return err
+		}
+		amount := new(big.Int).Sub(balance, c.gasReserve)
+		if amount.Cmp(collected) > 0 {
+			amount.Set(collected)
+		}
+		entries := delegation.CompoundEntries(delegator, amount, delegations, c.target, c.minDelegation)
+		if compoundDryRun {
+			fmt.Println(common.ToJSONUnsafe(map[string]interface{}{"delegate": entries}, !noPrettyOutput))
+			return nil
+		}
+		if len(entries) == 0 && amount.Sign() > 0 {
+			return errors.New("no delegations to spread the rewards over, give --validator-addr")
+		}
+		if amount.Cmp(c.minDelegation) < 0 {
+			fmt.Printf(
+				"Collected %s ITC but only %s ITC can be delegated after the gas reserve, retrying next round\n",
+				state.Collected, common.FormatITC(amount),
+			)
+			return state.Save(c.statePath)
+		}
+		if err := checkBatchCapacity(entries); err != nil {
+			return err
+		}
+		state.Plan(entries)
+		if err := state.Save(c.statePath); err != nil {
+			return err
+		}
+	}
+
+	for len(state.Pending) > 0 {
+		pending := &state.Pending[0]
+		if pending.TxHash == "" {
+			amount, err := pending.Atto()
+			if err != nil {
+				return err
+			}
+			txHash, err := c.send(networkHandler, func() (staking.Directive, interface{}) {
+				return staking.DirectiveDelegate, staking.Delegate{
+					address.Parse(pending.Delegator),
+					address.Parse(pending.Validator),
+					amount,
+				}
+			})
+			if err != nil {
+				return err
+			}
+			pending.TxHash = txHash
+			if err := state.Save(c.statePath); err != nil {
+				return err
+			}
+		}
+		if resend, err := c.wait(networkHandler, pending.TxHash); err != nil {
+			if resend {
+				pending.TxHash = ""
+				if saveErr := state.Save(c.statePath); saveErr != nil {
+					return saveErr
+				}
+			}
+			return errors.Wrapf(err, "delegation of %s ITC to %s not confirmed", pending.Amount, pending.Validator)
+		}
+		if err := state.Delegated(); err != nil {
+			return err
+		}
+		if err := state.Save(c.statePath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// send signs and sends one staking transaction of the delegator
+func (c *compounder) send(
+	networkHandler *rpc.HTTPMessenger, payload func() (staking.Directive, interface{}),
+) (string, error) {
+	nonce, err := getNonce(delegatorAddress.String(), networkHandler)
+	if err != nil {
+		return "", err
+	}
+	stakingTx, err := createStakingTransaction(nonce, payload)
+	if err != nil {
+		return "", err
+	}
+	passphrase = c.passphrase
+	return sendStakingTransaction(stakingTx, networkHandler, delegatorAddress)
+}
+
+// wait waits for txHash to be confirmed. resend reports whether the
+// transaction failed or was rejected, so sending it again can not spend
+// twice. A transaction that is only late keeps being waited for next round.
+func (c *compounder) wait(networkHandler *rpc.HTTPMessenger, txHash string) (resend bool, err error) {
+	receipt, txErrors, err := transaction.WaitForConfirmation(
+		networkHandler, txHash, transaction.NewConfirmationOptions(timeout, confirmations),
+	)
+	if err == nil {
+		return false, nil
+	}
+	for _, txError := range txErrors {
+		fmt.Println(txError.Error().Error())
+	}
+	if errors.Is(err, transaction.ErrTransactionFailed) {
+		return true, err
+	}
+	return receipt == nil && len(txErrors) > 0 && !errors.Is(err, transaction.ErrConfirmationTimeout), err
+}
+
+func autoCompoundCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "auto-compound",
+		Short: "periodically collect rewards and delegate them again",
+		Args:  cobra.ExactArgs(0),
+		Long: `
+Check the rewards of a delegator every --interval and, once they pass
+--threshold, collect them and delegate the proceeds again, either all to
+--validator-addr or spread over the current delegations by their size. The
+balance keeps --gas-reserve back for gas. Every transaction is saved before
+it is waited for, so a restart waits for it again, delegates rewards collected
+before the restart and only resends the delegations that failed. Use --dry-run to
+print what would be sent, and --once to run a single round.
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if timeout == 0 {
+				return errors.New("auto-compound waits for each transaction, --timeout can not be 0")
+			}
+			c := &compounder{}
+			var err error
+			if c.threshold, err = delegation.ParseITC(compoundThreshold); err != nil {
+				return err
+			}
+			if c.gasReserve, err = delegation.ParseITC(compoundGasReserve); err != nil {
+				return err
+			}
+			if c.minDelegation, err = delegation.ParseITC(compoundMinDelegation); err != nil {
+				return err
+			}
+			if cmd.Flags().Changed("validator-addr") {
+				c.target = validatorAddress.String()
+			}
+			routes, err := sharding.Structure(node)
+			if err != nil {
+				return err
+			}
+			c.beaconNode = beaconChainNode(routes)
+			c.statePath = compoundStateFile
+			if c.statePath == "" {
+				c.statePath = compoundStatePath(delegatorAddress.String())
+			}
+			if !compoundDryRun {
+				if c.passphrase, err = getPassphrase(); err != nil {
+					return err
+				}
+			}
+
+			for {
+				if err := c.round(); err != nil {
+					if compoundOnce {
+						return err
+					}
+					fmt.Printf("Round failed, retrying in %s: %s\n", compoundInterval, err)
+				}
+				if compoundOnce || compoundDryRun {
+					return nil
+				}
+				time.Sleep(compoundInterval)
+			}
+		},
+	}
+
+	cmd.Flags().Var(&delegatorAddress, "delegator-addr", "delegator's address")
+	cmd.Flags().Var(&validatorAddress, "validator-addr", "validator receiving all rewards, defaults to spreading them over the current delegations")
+	cmd.Flags().StringVar(&compoundThreshold, "threshold", "100", "rewards to wait for before collecting, in ITC")
+	cmd.Flags().DurationVar(&compoundInterval, "interval", time.Hour, "how often to check the rewards")
+	cmd.Flags().StringVar(&compoundGasReserve, "gas-reserve", "1", "balance kept back for gas, in ITC")
+	cmd.Flags().StringVar(&compoundMinDelegation, "min-delegation", "100", "smallest delegation the chain accepts, in ITC")
+	cmd.Flags().StringVar(&compoundStateFile, "state-file", "", "where to keep the progress, defaults to a file per delegator in the itc config directory")
+	cmd.Flags().BoolVar(&compoundDryRun, "dry-run", false, "print what would be collected and delegated without sending anything")
+	cmd.Flags().BoolVar(&compoundOnce, "once", false, "run a single round instead of running until stopped")
+	cmd.Flags().StringVar(&gasPrice, "gas-price", "100", "gas price to pay")
+	cmd.Flags().StringVar(&gasLimit, "gas-limit", "", "gas limit")
+	cmd.Flags().StringVar(&targetChain, "chain-id", "", "what chain ID to target")
+	cmd.Flags().Uint32Var(&timeout, "timeout", defaultTimeout, "set timeout in seconds to wait for each tx confirm")
+	cmd.Flags().Uint32Var(&confirmations, "confirmations", 1, confirmationsUsage)
+	cmd.Flags().BoolVar(&userProvidesPassphrase, "passphrase", false, ppPrompt)
+	cmd.Flags().StringVar(&passphraseFilePath, "passphrase-file", "", "path to a file containing the passphrase")
+
+	cmd.MarkFlagRequired("delegator-addr")
+	return cmd
+}
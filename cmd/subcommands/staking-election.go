@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"fmt"
+	"math/big"
+
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/intelchain-itc/intelchain/numeric"
+	"github.com/intelchain-itc/intelchain/staking/effective"
+	"github.com/intelchain-itc/itc-sdk/pkg/address"
+	"github.com/intelchain-itc/itc-sdk/pkg/common"
+	"github.com/intelchain-itc/itc-sdk/pkg/delegation"
+	"github.com/intelchain-itc/itc-sdk/pkg/sharding"
+	"github.com/intelchain-itc/itc-sdk/pkg/validator"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	electionRemoveAmount string
+	electionAddKeys      []string
+	electionRemoveKeys   []string
+	electionExtended     bool
+)
+
+// electionSlot is the outcome of one bls key of the bid
+type electionSlot struct {
+	Key            string `json:"bls-public-key"`
+	Elected        bool   `json:"elected"`
+	RawStake       string `json:"raw-stake,omitempty"`
+	EffectiveStake string `json:"effective-stake,omitempty"`
+}
+
+// electionOutcome is the predicted result of the next election for the
+// validator of a bid, amounts in ITC
+type electionOutcome struct {
+	Validator          string         `json:"validator"`
+	Stake              string         `json:"stake"`
+	StakePerSlot       string         `json:"stake-per-slot"`
+	Elected            bool           `json:"elected"`
+	ElectedSlots       int            `json:"elected-slots"`
+	Slots              []electionSlot `json:"slots"`
+	MedianStake        string         `json:"median-stake"`
+	LowestWinningStake string         `json:"lowest-winning-stake-per-slot"`
+	MaxExternalSlots   int            `json:"max-external-slots"`
+}
+
+// decToITC formats an atto amount held in a numeric.Dec as ITC
+func decToITC(d numeric.Dec) string {
+	if d.IsNil() {
+		return "0"
+	}
+	return common.FormatITC(d.TruncateInt())
+}
+
+// simulateElection runs the candidates through the same effective stake
+// auction the chain holds and reports how the validator of the bid fares
+func simulateElection(
+	snapshot *validator.Snapshot, candidates []validator.Candidate, bidder string,
+) (*electionOutcome, error) {
+	shortHand := map[ethCommon.Address]*effective.SlotOrder{}
+	var bid validator.Candidate
+	for _, c := range candidates {
+		order := &effective.SlotOrder{Stake: c.Stake, Percentage: numeric.ZeroDec()}
+		for _, k := range c.Keys {
+			key, err := parseSlotKey(k)
+			if err != nil {
+				return nil, errors.Wrapf(err, "bad bls key %s of %s", k, c.Validator)
+			}
+			order.SpreadAmong = append(order.SpreadAmong, *key)
+		}
+		shortHand[address.Parse(c.Validator)] = order
+		if c.Validator == bidder {
+			bid = c
+		}
+	}
+
+	median, picks := effective.Apply(shortHand, snapshot.MaxExternalSlots, electionExtended)
+	won := map[string]effective.SlotPurchase{}
+	var lowest *numeric.Dec
+	for i := range picks {
+		if lowest == nil || picks[i].RawStake.LT(*lowest) {
+			lowest = &picks[i].RawStake
+		}
+		if address.ToBech32(picks[i].Addr) == bidder {
+			won[validator.NormalizeBLSKey(picks[i].Key.Hex())] = picks[i]
+		}
+	}
+
+	perSlot := new(big.Int).Quo(bid.Stake, big.NewInt(int64(len(bid.Keys))))
+	outcome := &electionOutcome{
+		Validator:          bidder,
+		Stake:              common.FormatITC(bid.Stake),
+		StakePerSlot:       common.FormatITC(perSlot),
+		ElectedSlots:       len(won),
+		Elected:            len(won) == len(bid.Keys),
+		Slots:              []electionSlot{},
+		MedianStake:        decToITC(median),
+		LowestWinningStake: "0",
+		MaxExternalSlots:   snapshot.MaxExternalSlots,
+	}
+	if lowest != nil {
+		outcome.LowestWinningStake = decToITC(*lowest)
+	}
+	for _, k := range bid.Keys {
+		slot := electionSlot{Key: k}
+		if pick, ok := won[k]; ok {
+			slot.Elected = true
+			slot.RawStake = decToITC(pick.RawStake)
+			slot.EffectiveStake = decToITC(pick.EPoSStake)
+		}
+		outcome.Slots = append(outcome.Slots, slot)
+	}
+	return outcome, nil
+}
+
+func simulateElectionCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "simulate-election",
+		Short: "predict whether a bid would be elected and its effective stake",
+		Args:  cobra.ExactArgs(0),
+		Long: `
+Take the node's median raw stake snapshot of the next election, apply a
+hypothetical bid to it and run the effective stake auction on the result. The
+bid is a validator, new or already bidding, with stake added through --amount
+or withdrawn through --remove-amount and bls keys added or removed. Prints
+whether each key of the validator wins a slot, its effective stake, and the
+lowest stake per slot that still won, to size a self-delegation against.
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			added, err := delegation.ParseITC(stakingAmount)
+			if err != nil {
+				return err
+			}
+			removed, err := delegation.ParseITC(electionRemoveAmount)
+			if err != nil {
+				return err
+			}
+			for _, k := range append(append([]string{}, electionAddKeys...), electionRemoveKeys...) {
+				if _, err := parseSlotKey(k); err != nil {
+					return errors.Wrapf(err, "bad bls key %s", k)
+				}
+			}
+
+			routes, err := sharding.Structure(node)
+			if err != nil {
+				return err
+			}
+			snapshot, err := validator.MedianStakeSnapshot(beaconChainNode(routes))
+			if err != nil {
+				return err
+			}
+			candidates, err := snapshot.WithBid(validator.Bid{
+				Validator:  validatorAddress.String(),
+				Stake:      added.Sub(added, removed),
+				AddKeys:    electionAddKeys,
+				RemoveKeys: electionRemoveKeys,
+			})
+			if err != nil {
+				return err
+			}
+			outcome, err := simulateElection(snapshot, candidates, validatorAddress.String())
+			if err != nil {
+				return err
+			}
+			fmt.Println(common.ToJSONUnsafe(outcome, !noPrettyOutput))
+			return nil
+		},
+	}
+
+	cmd.Flags().Var(&validatorAddress, "validator-addr", "validator making the bid")
+	cmd.Flags().StringVar(&stakingAmount, "amount", "0", "stake added to the validator, in ITC")
+	cmd.Flags().StringVar(&electionRemoveAmount, "remove-amount", "0", "stake withdrawn from the validator, in ITC")
+	cmd.Flags().StringSliceVar(&electionAddKeys, "add-bls-key", []string{}, "bls public keys joining the auction")
+	cmd.Flags().StringSliceVar(&electionRemoveKeys, "remove-bls-key", []string{}, "bls public keys leaving the auction")
+	cmd.Flags().BoolVar(&electionExtended, "extended-bound", true, "bound effective stake to 65%-135% of the median instead of 85%-115%")
+
+	cmd.MarkFlagRequired("validator-addr")
+	return cmd
+}
@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"fmt"
+	"math/big"
+
+	staking "github.com/intelchain-itc/intelchain/staking/types"
+	"github.com/intelchain-itc/itc-sdk/pkg/address"
+	"github.com/intelchain-itc/itc-sdk/pkg/common"
+	"github.com/intelchain-itc/itc-sdk/pkg/delegation"
+	"github.com/intelchain-itc/itc-sdk/pkg/sharding"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	rebalanceTarget        []string
+	rebalanceMinDelegation string
+	rebalanceGasReserve    string
+	rebalanceExecute       bool
+)
+
+func rebalanceCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rebalance",
+		Short: "move delegations to a target allocation across validators",
+		Args:  cobra.ExactArgs(0),
+		Long: `
+Work out the undelegate and delegate transactions taking the delegations of a
+delegator to a target allocation, given as validator=weight pairs. Active and
+locked (pending undelegation) stake is spread by weight, plus --amount more
+from the balance. Delegations are funded from locked tokens first, which the
+chain redelegates, so the plan is carried out at once. The plan is printed
+unless --execute is given, which sends the undelegations, waits for them and
+then sends the delegations.
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			shares, err := delegation.ParseSplit(rebalanceTarget)
+			if err != nil {
+				return err
+			}
+			for _, share := range shares {
+				if err := new(itcAddress).Set(share.Validator); err != nil {
+					return errors.Wrapf(err, "target validator %s", share.Validator)
+				}
+			}
+			extra, err := delegation.ParseITC(stakingAmount)
+			if err != nil {
+				return err
+			}
+			minDelegation, err := delegation.ParseITC(rebalanceMinDelegation)
+			if err != nil {
+				return err
+			}
+			gasReserve, err := delegation.ParseITC(rebalanceGasReserve)
+			if err != nil {
+				return err
+			}
+
+			routes, err := sharding.Structure(node)
+			if err != nil {
+				return err
+			}
+			beaconNode := beaconChainNode(routes)
+			delegations, err := delegation.ByDelegator(beaconNode, delegatorAddress.String())
+			if err != nil {
+				return err
+			}
+			if extra.Sign() > 0 {
+				balance, err := delegation.Balance(beaconNode, delegatorAddress.String())
+				if err != nil {
+					return err
+				}
+				spendable := new(big.Int).Sub(balance, gasReserve)
+				if extra.Cmp(spendable) > 0 {
+					return fmt.Errorf(
+						"--amount %s is more than the %s ITC balance less the %s ITC gas reserve",
+						stakingAmount, common.FormatITC(balance), rebalanceGasReserve,
+					)
+				}
+			}
+
+			plan, err := delegation.PlanRebalance(
+				delegatorAddress.String(), delegations, shares,
+				delegation.RebalanceOptions{Extra: extra, MinDelegation: minDelegation},
+			)
+			if err != nil {
+				return err
+			}
+			fmt.Println(common.ToJSONUnsafe(plan, !noPrettyOutput))
+			undelegations := plan.Entries(delegation.ActionUndelegate)
+			toDelegate := plan.Entries(delegation.ActionDelegate)
+			if !rebalanceExecute || len(undelegations)+len(toDelegate) == 0 {
+				return nil
+			}
+
+			if timeout == 0 {
+				return errors.New("--execute waits for the undelegations, --timeout can not be 0")
+			}
+			keystorePassphrase, err := getPassphrase()
+			if err != nil {
+				return err
+			}
+			if len(undelegations) > 0 {
+				err := runStakingBatch(undelegations, keystorePassphrase, func(entry delegation.Entry, amount *big.Int) (staking.Directive, interface{}) {
+					return staking.DirectiveUndelegate, staking.Undelegate{
+						address.Parse(entry.Delegator),
+						address.Parse(entry.Validator),
+						amount,
+					}
+				})
+				if err != nil {
+					return errors.Wrap(err, "undelegations failed, no delegations were sent")
+				}
+			}
+			if len(toDelegate) == 0 {
+				return nil
+			}
+			if err := checkBatchCapacity(toDelegate); err != nil {
+				return err
+			}
+			return runStakingBatch(toDelegate, keystorePassphrase, func(entry delegation.Entry, amount *big.Int) (staking.Directive, interface{}) {
+				return staking.DirectiveDelegate, staking.Delegate{
+					address.Parse(entry.Delegator),
+					address.Parse(entry.Validator),
+					amount,
+				}
+			})
+		},
+	}
+
+	cmd.Flags().Var(&delegatorAddress, "delegator-addr", "delegator's address")
+	cmd.Flags().StringSliceVar(&rebalanceTarget, "target", []string{}, "target allocation as <validator>=<weight>,<validator>=<weight>")
+	cmd.Flags().StringVar(&stakingAmount, "amount", "0", "balance to stake on top of the current delegations")
+	cmd.Flags().StringVar(&rebalanceMinDelegation, "min-delegation", "100", "smallest delegation the chain accepts, in ITC")
+	cmd.Flags().StringVar(&rebalanceGasReserve, "gas-reserve", "1", "balance kept back for gas when staking --amount, in ITC")
+	cmd.Flags().BoolVar(&rebalanceExecute, "execute", false, "send the transactions instead of only printing the plan")
+	cmd.Flags().StringVar(&gasPrice, "gas-price", "100", "gas price to pay")
+	cmd.Flags().StringVar(&gasLimit, "gas-limit", "", "gas limit")
+	cmd.Flags().StringVar(&targetChain, "chain-id", "", "what chain ID to target")
+	cmd.Flags().Uint32Var(&timeout, "timeout", defaultTimeout, "set timeout in seconds to wait for each tx confirm")
+	cmd.Flags().Uint32Var(&confirmations, "confirmations", 1, confirmationsUsage)
+	cmd.Flags().BoolVar(&userProvidesPassphrase, "passphrase", false, ppPrompt)
+	cmd.Flags().StringVar(&passphraseFilePath, "passphrase-file", "", "path to a file containing the passphrase")
+
+	for _, flagName := range [...]string{"delegator-addr", "target"} {
+		cmd.MarkFlagRequired(flagName)
+	}
+	return cmd
+}
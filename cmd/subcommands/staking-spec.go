@@ -0,0 +1,260 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/intelchain-itc/intelchain/crypto/bls"
+	"github.com/intelchain-itc/intelchain/shard"
+	"github.com/intelchain-itc/itc-sdk/pkg/common"
+	"github.com/intelchain-itc/itc-sdk/pkg/keys"
+	"github.com/intelchain-itc/itc-sdk/pkg/sharding"
+	"github.com/intelchain-itc/itc-sdk/pkg/validator"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	validatorSpecPath string
+	specDiffOnly      bool
+	// blsKeyDirs is the directory of each bls key file named in a spec,
+	// keyed by public key
+	blsKeyDirs = map[string]string{}
+)
+
+var (
+	// createValidatorFlags must all be given to create a validator without
+	// a spec
+	createValidatorFlags = []string{
+		"name", "identity", "website", "security-contact",
+		"details", "rate", "max-rate", "max-change-rate",
+		"min-self-delegation", "max-total-delegation",
+		"validator-addr", "bls-pubkeys", "amount",
+	}
+	// specFlags are the flags a validator spec takes the place of
+	specFlags = []string{
+		"name", "identity", "website", "security-contact", "details",
+		"rate", "max-rate", "max-change-rate",
+		"min-self-delegation", "max-total-delegation", "amount",
+		"bls-pubkeys", "bls-pubkeys-dir", "add-bls-key", "remove-bls-key", "active",
+	}
+)
+
+// requireFlags fails like cobra does for required flags, for commands where
+// a spec can take the place of the flags
+func requireFlags(cmd *cobra.Command, names []string) error {
+	missing := []string{}
+	for _, name := range names {
+		if !cmd.Flags().Changed(name) {
+			missing = append(missing, fmt.Sprintf("%q", name))
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("required flag(s) %s not set", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// beaconChainNode returns the endpoint of the beacon chain among routes, or
+// the node itself when it is given as an IP address
+func beaconChainNode(routes []sharding.RPCRoutes) string {
+	if !checkNodeInput(node) {
+		for _, route := range routes {
+			if uint32(route.ShardID) == shard.BeaconChainShardID {
+				return route.HTTP
+			}
+		}
+	}
+	return node
+}
+
+// loadValidatorSpec reads the spec given with --spec and takes the validator
+// address from it unless the flag names the same one
+func loadValidatorSpec(cmd *cobra.Command) (*validator.Spec, error) {
+	for _, name := range specFlags {
+		if f := cmd.Flags().Lookup(name); f != nil && f.Changed {
+			return nil, fmt.Errorf("--%s can not be combined with --spec, set it in the spec", name)
+		}
+	}
+	spec, err := validator.LoadSpec(validatorSpecPath)
+	if err != nil {
+		return nil, err
+	}
+	if spec.Address != "" {
+		if cmd.Flags().Changed("validator-addr") && validatorAddress.String() != spec.Address {
+			return nil, fmt.Errorf(
+				"--validator-addr %s does not match %s in the spec", validatorAddress.String(), spec.Address,
+			)
+		}
+		if err := validatorAddress.Set(spec.Address); err != nil {
+			return nil, err
+		}
+	}
+	if validatorAddress.String() == "" {
+		return nil, errors.New("validator address missing, set validator-addr in the spec or pass --validator-addr")
+	}
+	return spec, nil
+}
+
+// specBlsKeys returns the public keys of the bls key files of a spec, which
+// are named after their public key like the files in --bls-pubkeys-dir.
+// The directory of each is remembered for signing with the key.
+func specBlsKeys(spec *validator.Spec) ([]string, error) {
+	if spec.BLSKeyFiles == nil {
+		return nil, nil
+	}
+	publicKeys := []string{}
+	for _, file := range spec.BLSKeyFiles {
+		if !strings.HasSuffix(file, ".key") {
+			return nil, fmt.Errorf("bls key file %s must be named <public key>.key", file)
+		}
+		publicKey := validator.NormalizeBLSKey(strings.TrimSuffix(filepath.Base(file), ".key"))
+		if _, err := parseSlotKey(publicKey); err != nil {
+			return nil, errors.Wrapf(err, "bls key file %s must be named <public key>.key", file)
+		}
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		if inFile, err := keys.BlsKeyFilePublicKey(data); err == nil &&
+			validator.NormalizeBLSKey(inFile) != publicKey {
+			return nil, fmt.Errorf("bls key file %s holds the key %s", file, inFile)
+		}
+		blsKeyDirs[publicKey] = filepath.Dir(file)
+		publicKeys = append(publicKeys, publicKey)
+	}
+	return publicKeys, nil
+}
+
+// blsKeyDir is the directory of the key file of a bls public key
+func blsKeyDir(publicKey string) string {
+	if dir, ok := blsKeyDirs[validator.NormalizeBLSKey(publicKey)]; ok {
+		return dir
+	}
+	return blsPubKeyDir
+}
+
+// signBlsKeys proves ownership of each bls public key with its key file
+func signBlsKeys(publicKeys []string) ([]bls.SerializedSignature, error) {
+	sigs := make([]bls.SerializedSignature, len(publicKeys))
+	for i, publicKey := range publicKeys {
+		sig, err := keys.VerifyBLS(strings.TrimPrefix(publicKey, "0x"), blsKeyDir(publicKey))
+		if err != nil {
+			return nil, err
+		}
+		sigs[i] = sig
+	}
+	return sigs, nil
+}
+
+// applyCreateSpec fills the create-validator flags from a spec
+func applyCreateSpec(cmd *cobra.Command) error {
+	spec, err := loadValidatorSpec(cmd)
+	if err != nil {
+		return err
+	}
+	missing := []string{}
+	for _, field := range []struct{ name, value string }{
+		{"description.name", spec.Description.Name},
+		{"commission.rate", spec.Commission.Rate},
+		{"commission.max-rate", spec.Commission.MaxRate},
+		{"commission.max-change-rate", spec.Commission.MaxChangeRate},
+		{"delegation.amount", spec.Delegation.Amount},
+	} {
+		if field.value == "" {
+			missing = append(missing, field.name)
+		}
+	}
+	if len(spec.BLSKeyFiles) == 0 {
+		missing = append(missing, "bls-key-files")
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("validator spec is missing %s", strings.Join(missing, ", "))
+	}
+
+	validatorName = spec.Description.Name
+	validatorIdentity = spec.Description.Identity
+	validatorWebsite = spec.Description.Website
+	validatorSecurityContact = spec.Description.SecurityContact
+	validatorDetails = spec.Description.Details
+	commisionRateStr = spec.Commission.Rate
+	commisionMaxRateStr = spec.Commission.MaxRate
+	commisionMaxChangeRateStr = spec.Commission.MaxChangeRate
+	stakingAmount = spec.Delegation.Amount
+	if spec.Delegation.MinSelfDelegation != "" {
+		minSelfDelegation = spec.Delegation.MinSelfDelegation
+	}
+	if spec.Delegation.MaxTotalDelegation != "" {
+		maxTotalDelegation = spec.Delegation.MaxTotalDelegation
+	}
+	stakingBlsPubKeys, err = specBlsKeys(spec)
+	return err
+}
+
+// applyEditSpec diffs a spec against the validator on chain, prints the
+// changes and sets the edit-validator flags to exactly those changes. It
+// reports whether there is nothing left to send.
+func applyEditSpec(cmd *cobra.Command) (bool, error) {
+	spec, err := loadValidatorSpec(cmd)
+	if err != nil {
+		return false, err
+	}
+	publicKeys, err := specBlsKeys(spec)
+	if err != nil {
+		return false, err
+	}
+	routes, err := sharding.Structure(node)
+	if err != nil {
+		return false, err
+	}
+	info, err := validator.GetInformation(beaconChainNode(routes), validatorAddress.String())
+	if err != nil {
+		return false, errors.Wrapf(err, "validator address not found: %s", validatorAddress.String())
+	}
+	changes, err := spec.Diff(info, publicKeys)
+	if err != nil {
+		return false, err
+	}
+	fmt.Println(common.ToJSONUnsafe(changes, !noPrettyOutput))
+	if len(changes) == 0 {
+		fmt.Println("Validator already matches the spec, nothing to send")
+		return true, nil
+	}
+	if specDiffOnly {
+		return true, nil
+	}
+
+	validatorName, validatorIdentity, validatorWebsite = "", "", ""
+	validatorSecurityContact, validatorDetails = "", ""
+	commisionRateStr, minSelfDelegation, maxTotalDelegation = "", "", ""
+	slotKeyToAdd, slotKeyToRemove, active = "", "", ""
+	for _, change := range changes {
+		switch change.Field {
+		case "name":
+			validatorName = change.Desired
+		case "identity":
+			validatorIdentity = change.Desired
+		case "website":
+			validatorWebsite = change.Desired
+		case "security-contact":
+			validatorSecurityContact = change.Desired
+		case "details":
+			validatorDetails = change.Desired
+		case "rate":
+			commisionRateStr = change.Desired
+		case "min-self-delegation":
+			minSelfDelegation = change.Desired
+		case "max-total-delegation":
+			maxTotalDelegation = change.Desired
+		case "add-bls-key":
+			slotKeyToAdd = change.Desired
+		case "remove-bls-key":
+			slotKeyToRemove = change.Current
+		case "active":
+			active = change.Desired
+		}
+	}
+	return false, nil
+}
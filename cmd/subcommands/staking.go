@@ -3,6 +3,8 @@ package cmd
 import (
 	"fmt"
 	"math/big"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -11,7 +13,6 @@ import (
 	"github.com/ethereum/go-ethereum/rlp"
 	bls_core "github.com/intelchain-itc/bls/ffi/go/bls"
 	"github.com/intelchain-itc/intelchain/accounts"
-	"github.com/intelchain-itc/intelchain/accounts/keystore"
 	"github.com/intelchain-itc/intelchain/common/denominations"
 	"github.com/intelchain-itc/intelchain/core"
 	"github.com/intelchain-itc/intelchain/crypto/bls"
@@ -21,11 +22,15 @@ import (
 	staking "github.com/intelchain-itc/intelchain/staking/types"
 	"github.com/intelchain-itc/itc-sdk/pkg/address"
 	"github.com/intelchain-itc/itc-sdk/pkg/common"
+	"github.com/intelchain-itc/itc-sdk/pkg/delegation"
 	"github.com/intelchain-itc/itc-sdk/pkg/keys"
 	"github.com/intelchain-itc/itc-sdk/pkg/ledger"
 	"github.com/intelchain-itc/itc-sdk/pkg/rpc"
+	"github.com/intelchain-itc/itc-sdk/pkg/sharding"
 	"github.com/intelchain-itc/itc-sdk/pkg/store"
 	"github.com/intelchain-itc/itc-sdk/pkg/transaction"
+	"github.com/intelchain-itc/itc-sdk/pkg/validator"
+	homedir "github.com/mitchellh/go-homedir"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
@@ -58,6 +63,12 @@ var (
 	validatorAddress          itcAddress
 	stakingAmount             string
 	active                    string
+	rotationShard             uint32
+	rotationDeadline          time.Duration
+	rotationPollInterval      time.Duration
+	rotationStateFile         string
+	rotationRestart           bool
+	rotationPlanOnly          bool
 	itcAsDec                  = numeric.NewDec(denominations.Itc)
 	nanoAsDec                 = numeric.NewDec(denominations.Ticks)
 )
@@ -118,85 +129,88 @@ func createStakingTransaction(nonce uint64, f staking.StakeMsgFulfiller) (*staki
 func handleStakingTransaction(
 	stakingTx *staking.StakingTransaction, networkHandler *rpc.HTTPMessenger, signerAddress itcAddress,
 ) error {
+	r, err := sendStakingTransaction(stakingTx, networkHandler, signerAddress)
+	if err != nil {
+		return err
+	}
+	if timeout > 0 {
+		if err := confirmTx(networkHandler, timeout, r); err != nil {
+			fmt.Println(fmt.Sprintf(`{"transaction-hash":"%s"}`, r))
+			return err
+		}
+	} else {
+		fmt.Println(fmt.Sprintf(`{"transaction-receipt":"%s"}`, r))
+	}
+	return nil
+}
+
+// sendStakingTransaction signs the staking transaction and sends it off,
+// returning its hash
+func sendStakingTransaction(
+	stakingTx *staking.StakingTransaction, networkHandler *rpc.HTTPMessenger, signerAddress itcAddress,
+) (string, error) {
 	var (
-		ks     *keystore.KeyStore
+		ks     store.Keystore
 		acct   *accounts.Account
 		signed *staking.StakingTransaction
 		err    error
 	)
 
 	from := signerAddress.String()
+	if err := verifyCustomChain(node); err != nil {
+		return "", err
+	}
 
 	if useLedgerWallet {
 		signerAddr := ""
 		signed, signerAddr, err = ledger.SignStakingTx(stakingTx, chainName.chainID.Value)
 		if err != nil {
-			return err
+			return "", err
 		}
 
 		if strings.Compare(signerAddr, from) != 0 {
-			return errors.New("error : delegator address doesn't match with ledger hardware addresss")
+			return "", errors.New("error : delegator address doesn't match with ledger hardware addresss")
 		}
 	} else {
 		ks, acct, err = store.UnlockedKeystore(from, passphrase)
 		if err != nil {
-			return err
+			return "", err
 		}
 		signed, err = ks.SignStakingTx(*acct, stakingTx, chainName.chainID.Value)
 	}
 
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	enc, err := rlp.EncodeToBytes(signed)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	hexSignature := hexutil.Encode(enc)
 	reply, err := networkHandler.SendRPC(rpc.Method.SendRawStakingTransaction, []interface{}{hexSignature})
 	if err != nil {
-		return err
+		return "", err
 	}
 	r, _ := reply["result"].(string)
-	if timeout > 0 {
-		if err := confirmTx(networkHandler, timeout, r); err != nil {
-			fmt.Println(fmt.Sprintf(`{"transaction-hash":"%s"}`, r))
-			return err
-		}
-	} else {
-		fmt.Println(fmt.Sprintf(`{"transaction-receipt":"%s"}`, r))
-	}
-	return nil
+	return r, nil
 }
 
 func confirmTx(networkHandler *rpc.HTTPMessenger, confirmWaitTime uint32, txHash string) error {
-	start := int(confirmWaitTime)
-	for {
-		r, _ := networkHandler.SendRPC(rpc.Method.GetTransactionReceipt, []interface{}{txHash})
-		if r["result"] != nil {
-			fmt.Println(common.ToJSONUnsafe(r, true))
-			return nil
-		}
-		if start < 0 {
-			transactionErrors, _ := transaction.GetError(txHash, networkHandler)
-			for _, txError := range transactionErrors {
-				fmt.Println(txError.Error().Error())
-			}
-			fmt.Println("Try increasing the `timeout` or look for the transaction receipt with `itc blockchain transaction-receipt <txHash>`")
-			return fmt.Errorf("could not confirm %s even after %d seconds", txHash, confirmWaitTime)
-		}
-		transactionErrors, _ := transaction.GetError(txHash, networkHandler)
-		if len(transactionErrors) > 0 {
-			for _, txError := range transactionErrors {
-				fmt.Println(txError.Error().Error())
-			}
-			return fmt.Errorf("staking transaction error")
-		}
-		time.Sleep(time.Second * 2)
-		start = start - 2
+	receipt, transactionErrors, err := transaction.WaitForConfirmation(
+		networkHandler, txHash, transaction.NewConfirmationOptions(confirmWaitTime, confirmations),
+	)
+	for _, txError := range transactionErrors {
+		fmt.Println(txError.Error().Error())
 	}
+	if receipt != nil {
+		fmt.Println(common.ToJSONUnsafe(receipt, true))
+	}
+	if errors.Is(err, transaction.ErrConfirmationTimeout) {
+		fmt.Println("Try increasing the `timeout` or look for the transaction receipt with `itc blockchain transaction-receipt <txHash>`")
+	}
+	return err
 }
 
 func delegationAmountSanityCheck(minSelfDelegation *numeric.Dec, maxTotalDelegation *numeric.Dec, amount *numeric.Dec) error {
@@ -309,6 +323,138 @@ func validateBlsKeyInput(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// parseSlotKey reads the hex of a bls public key into its serialized form
+func parseSlotKey(key string) (*bls.SerializedPublicKey, error) {
+	blsKey := new(bls_core.PublicKey)
+	if err := blsKey.DeserializeHexStr(strings.TrimPrefix(key, "0x")); err != nil {
+		return nil, err
+	}
+	shardKey := bls.SerializedPublicKey{}
+	shardKey.FromLibBLSPublicKey(blsKey)
+	return &shardKey, nil
+}
+
+// rotationChain carries out a bls key rotation of validatorAddress with
+// edit-validator transactions on the beacon chain
+type rotationChain struct {
+	beaconNode     string
+	networkHandler *rpc.HTTPMessenger
+}
+
+func (c rotationChain) Information() (*validator.Information, error) {
+	return validator.GetInformation(c.beaconNode, validatorAddress.String())
+}
+
+func (c rotationChain) Epoch() (uint64, error) {
+	return validator.CurrentEpoch(c.beaconNode)
+}
+
+func (c rotationChain) AddKey(key string) (string, error) {
+	shardKey, err := parseSlotKey(key)
+	if err != nil {
+		return "", err
+	}
+	sig, err := keys.VerifyBLS(key, blsPubKeyDir)
+	if err != nil {
+		return "", err
+	}
+	fmt.Printf("Adding bls key %s\n", key)
+	return c.editSlotKeys(nil, shardKey, &sig)
+}
+
+func (c rotationChain) RemoveKey(key string) (string, error) {
+	shardKey, err := parseSlotKey(key)
+	if err != nil {
+		return "", err
+	}
+	fmt.Printf("Removing bls key %s\n", key)
+	return c.editSlotKeys(shardKey, nil, nil)
+}
+
+// editSlotKeys sends an edit-validator transaction changing nothing but the
+// slot keys and waits for it to be confirmed
+func (c rotationChain) editSlotKeys(
+	toRemove, toAdd *bls.SerializedPublicKey, toAddSig *bls.SerializedSignature,
+) (string, error) {
+	payloadMaker := func() (staking.Directive, interface{}) {
+		return staking.DirectiveEditValidator, staking.EditValidator{
+			address.Parse(validatorAddress.String()),
+			staking.Description{},
+			nil,
+			nil,
+			nil,
+			toRemove,
+			toAdd,
+			toAddSig,
+			effective.Nil,
+		}
+	}
+	nonce, err := getNonce(validatorAddress.String(), c.networkHandler)
+	if err != nil {
+		return "", err
+	}
+	stakingTx, err := createStakingTransaction(nonce, payloadMaker)
+	if err != nil {
+		return "", err
+	}
+	txHash, err := sendStakingTransaction(stakingTx, c.networkHandler, validatorAddress)
+	if err != nil {
+		return "", err
+	}
+	return txHash, confirmTx(c.networkHandler, timeout, txHash)
+}
+
+// rotationStatePath is where the progress of rotating the bls keys of a
+// validator is kept unless --state-file is given
+func rotationStatePath(validatorAddr string) string {
+	uDir, _ := homedir.Dir()
+	return filepath.Join(uDir, common.DefaultConfigDirName, "rotations", validatorAddr+".json")
+}
+
+// loadOrPlanRotation resumes the rotation saved at statePath or plans a new
+// one, checking the new key maps to the expected shard
+func loadOrPlanRotation(cmd *cobra.Command, statePath string, shardCount int) (*validator.Rotation, error) {
+	r, err := validator.LoadRotation(statePath)
+	switch {
+	case err == nil && !rotationRestart:
+		if !r.Matches(validatorAddress.String(), slotKeyToRemove, slotKeyToAdd) {
+			return nil, fmt.Errorf(
+				"%w: %s replaces %s with %s, pass --restart to discard it",
+				validator.ErrRotationMismatch, statePath, r.OldKey, r.NewKey,
+			)
+		}
+		if cmd.Flags().Changed("deadline") {
+			r.Deadline = time.Now().Add(rotationDeadline)
+		}
+		fmt.Printf("Resuming rotation from %s at stage %s\n", statePath, r.Stage)
+		return r, nil
+	case err != nil && !os.IsNotExist(err):
+		return nil, err
+	}
+
+	oldShard, err := validator.ShardForKey(slotKeyToRemove, shardCount)
+	if err != nil {
+		return nil, err
+	}
+	newShard, err := validator.ShardForKey(slotKeyToAdd, shardCount)
+	if err != nil {
+		return nil, err
+	}
+	expectedShard := oldShard
+	if cmd.Flags().Changed("shard") {
+		expectedShard = rotationShard
+	}
+	if newShard != expectedShard {
+		return nil, fmt.Errorf(
+			"new bls key maps to shard %d but the old key is on shard %d, the node running it must sync shard %d; pass --shard %d to go ahead",
+			newShard, oldShard, newShard, newShard,
+		)
+	}
+	return validator.NewRotation(
+		validatorAddress.String(), slotKeyToRemove, slotKeyToAdd, newShard, time.Now().Add(rotationDeadline),
+	), nil
+}
+
 func stakingSubCommands() []*cobra.Command {
 
 	subCmdNewValidator := &cobra.Command{
@@ -320,6 +466,14 @@ Create a new validator"
 `,
 		PreRunE: validateBlsKeyInput,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if validatorSpecPath != "" {
+				if err := applyCreateSpec(cmd); err != nil {
+					return err
+				}
+			} else if err := requireFlags(cmd, createValidatorFlags); err != nil {
+				return err
+			}
+
 			networkHandler, err := handlerForShard(0, node)
 			if err != nil {
 				return err
@@ -351,7 +505,7 @@ Create a new validator"
 				blsPubKeys[i].FromLibBLSPublicKey(blsPubKey)
 			}
 
-			blsSigs, err := keys.VerifyBLSKeys(stakingBlsPubKeys, blsPubKeyDir)
+			blsSigs, err := signBlsKeys(stakingBlsPubKeys)
 			if err != nil {
 				return err
 			}
@@ -463,18 +617,15 @@ Create a new validator"
 		&timeout, "timeout",
 		defaultTimeout, "set timeout in seconds. Set to 0 to not wait for tx confirm",
 	)
+	subCmdNewValidator.Flags().Uint32Var(&confirmations, "confirmations", 1, confirmationsUsage)
 	subCmdNewValidator.Flags().BoolVar(&userProvidesPassphrase, "passphrase", false, ppPrompt)
 	subCmdNewValidator.Flags().StringVar(
 		&passphraseFilePath, "passphrase-file", "", "path to a file containing the passphrase",
 	)
 
-	for _, flagName := range [...]string{
-		"name", "identity", "website", "security-contact",
-		"details", "rate", "max-rate", "max-change-rate",
-		"min-self-delegation", "max-total-delegation",
-		"validator-addr", "bls-pubkeys", "amount"} {
-		subCmdNewValidator.MarkFlagRequired(flagName)
-	}
+	subCmdNewValidator.Flags().StringVar(
+		&validatorSpecPath, "spec", "", "YAML or JSON validator spec to use in place of the validator flags",
+	)
 
 	subCmdEditValidator := &cobra.Command{
 		Use:     "edit-validator",
@@ -483,6 +634,15 @@ Create a new validator"
 		Args:    cobra.ExactArgs(0),
 		PreRunE: validateBlsKeyInput,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if validatorSpecPath != "" {
+				done, err := applyEditSpec(cmd)
+				if err != nil || done {
+					return err
+				}
+			} else if err := requireFlags(cmd, []string{"validator-addr"}); err != nil {
+				return err
+			}
+
 			networkHandler, err := handlerForShard(shard.BeaconChainShardID, node)
 			if err != nil {
 				return err
@@ -499,30 +659,21 @@ Create a new validator"
 
 			var shardPubKeyRemove *bls.SerializedPublicKey
 			if slotKeyToRemove != "" {
-				blsKey := new(bls_core.PublicKey)
-				err = blsKey.DeserializeHexStr(strings.TrimPrefix(slotKeyToRemove, "0x"))
+				shardPubKeyRemove, err = parseSlotKey(slotKeyToRemove)
 				if err != nil {
 					return err
 				}
-				shardKey := bls.SerializedPublicKey{}
-				shardKey.FromLibBLSPublicKey(blsKey)
-				shardPubKeyRemove = &shardKey
 			}
 
 			var shardPubKeyAdd *bls.SerializedPublicKey
 			var sigBls *bls.SerializedSignature
 			if slotKeyToAdd != "" {
-				blsKey := new(bls_core.PublicKey)
-				err = blsKey.DeserializeHexStr(strings.TrimPrefix(slotKeyToAdd, "0x"))
+				shardPubKeyAdd, err = parseSlotKey(slotKeyToAdd)
 				if err != nil {
 					return err
 				}
 
-				shardKey := bls.SerializedPublicKey{}
-				shardKey.FromLibBLSPublicKey(blsKey)
-				shardPubKeyAdd = &shardKey
-
-				sig, err := keys.VerifyBLS(strings.TrimPrefix(slotKeyToAdd, "0x"), blsPubKeyDir)
+				sig, err := keys.VerifyBLS(strings.TrimPrefix(slotKeyToAdd, "0x"), blsKeyDir(slotKeyToAdd))
 				if err != nil {
 					return err
 				}
@@ -638,10 +789,106 @@ Create a new validator"
 	subCmdEditValidator.Flags().StringVar(&inputNonce, "nonce", "", "set nonce for transaction")
 	subCmdEditValidator.Flags().StringVar(&targetChain, "chain-id", "", "what chain ID to target")
 	subCmdEditValidator.Flags().Uint32Var(&timeout, "timeout", defaultTimeout, "set timeout in seconds. Set to 0 to not wait for tx confirm")
+	subCmdEditValidator.Flags().Uint32Var(&confirmations, "confirmations", 1, confirmationsUsage)
 	subCmdEditValidator.Flags().BoolVar(&userProvidesPassphrase, "passphrase", false, ppPrompt)
 	subCmdEditValidator.Flags().StringVar(&passphraseFilePath, "passphrase-file", "", "path to a file containing the passphrase")
 
-	subCmdEditValidator.MarkFlagRequired("validator-addr")
+	subCmdEditValidator.Flags().StringVar(
+		&validatorSpecPath, "spec", "", "YAML or JSON validator spec, only the fields differing from the chain are edited",
+	)
+	subCmdEditValidator.Flags().BoolVar(&specDiffOnly, "diff", false, "with --spec, print the changes without sending them")
+
+	subCmdRotateBlsKey := &cobra.Command{
+		Use:   "rotate-bls-key",
+		Short: "replace a bls key of a validator once its successor is elected",
+		Args:  cobra.ExactArgs(0),
+		Long: `
+Replace a bls key of a validator: add the new key, wait across epochs until it
+is elected, then remove the old key. Progress is saved after every step, so an
+interrupted rotation picks up where it left off when run again with the same
+keys. Use --plan to only check the keys and print the steps left.
+`,
+		PreRunE: validateBlsKeyInput,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if timeout == 0 {
+				return errors.New("rotate-bls-key waits for each transaction, --timeout can not be 0")
+			}
+			routes, err := sharding.Structure(node)
+			if err != nil {
+				return err
+			}
+			beaconNode := beaconChainNode(routes)
+			for _, key := range []string{slotKeyToRemove, slotKeyToAdd} {
+				if _, err := parseSlotKey(key); err != nil {
+					return errors.Wrapf(err, "bad bls key %s", key)
+				}
+			}
+
+			statePath := rotationStateFile
+			if statePath == "" {
+				statePath = rotationStatePath(validatorAddress.String())
+			}
+			r, err := loadOrPlanRotation(cmd, statePath, len(routes))
+			if err != nil {
+				return err
+			}
+			if rotationPlanOnly {
+				fmt.Println(common.ToJSONUnsafe(r, !noPrettyOutput))
+				return nil
+			}
+			if err := r.Save(statePath); err != nil {
+				return err
+			}
+			if r.Stage == validator.RotationDone {
+				fmt.Println(common.ToJSONUnsafe(r, !noPrettyOutput))
+				return nil
+			}
+
+			passphrase, err = getPassphrase()
+			if err != nil {
+				return err
+			}
+			chain := rotationChain{beaconNode, rpc.NewHTTPHandler(beaconNode)}
+			for r.Stage != validator.RotationDone {
+				stage := r.Stage
+				if err := r.Step(chain, time.Now()); err != nil {
+					return err
+				}
+				if err := r.Save(statePath); err != nil {
+					return err
+				}
+				if r.Stage == stage {
+					time.Sleep(rotationPollInterval)
+				} else {
+					fmt.Printf("Rotation of %s is now at stage %s\n", validatorAddress.String(), r.Stage)
+				}
+			}
+			fmt.Println(common.ToJSONUnsafe(r, !noPrettyOutput))
+			return nil
+		},
+	}
+
+	subCmdRotateBlsKey.Flags().Var(&validatorAddress, "validator-addr", "validator's staking address")
+	subCmdRotateBlsKey.Flags().StringVar(&slotKeyToRemove, "old-bls-key", "", "BLS pubkey to retire")
+	subCmdRotateBlsKey.Flags().StringVar(&slotKeyToAdd, "new-bls-key", "", "BLS pubkey replacing it")
+	subCmdRotateBlsKey.Flags().StringVar(&blsPubKeyDir, "bls-pubkeys-dir", "", "directory to bls pubkeys storing pub.key, pub.pass files")
+	subCmdRotateBlsKey.Flags().Uint32Var(&rotationShard, "shard", 0, "shard the new key is meant for, defaults to the shard of the old key")
+	subCmdRotateBlsKey.Flags().DurationVar(&rotationDeadline, "deadline", 48*time.Hour, "how long the new key has to be elected, from now")
+	subCmdRotateBlsKey.Flags().DurationVar(&rotationPollInterval, "poll-interval", time.Minute, "how often to check whether the new key is elected")
+	subCmdRotateBlsKey.Flags().StringVar(&rotationStateFile, "state-file", "", "where to keep the progress, defaults to a file per validator in the itc config directory")
+	subCmdRotateBlsKey.Flags().BoolVar(&rotationRestart, "restart", false, "discard saved progress and plan a new rotation")
+	subCmdRotateBlsKey.Flags().BoolVar(&rotationPlanOnly, "plan", false, "print the rotation and its stage without sending anything")
+	subCmdRotateBlsKey.Flags().StringVar(&gasPrice, "gas-price", "100", "gas price to pay")
+	subCmdRotateBlsKey.Flags().StringVar(&gasLimit, "gas-limit", "", "gas limit")
+	subCmdRotateBlsKey.Flags().StringVar(&targetChain, "chain-id", "", "what chain ID to target")
+	subCmdRotateBlsKey.Flags().Uint32Var(&timeout, "timeout", defaultTimeout, "set timeout in seconds to wait for each tx confirm")
+	subCmdRotateBlsKey.Flags().Uint32Var(&confirmations, "confirmations", 1, confirmationsUsage)
+	subCmdRotateBlsKey.Flags().BoolVar(&userProvidesPassphrase, "passphrase", false, ppPrompt)
+	subCmdRotateBlsKey.Flags().StringVar(&passphraseFilePath, "passphrase-file", "", "path to a file containing the passphrase")
+
+	for _, flagName := range [...]string{"validator-addr", "old-bls-key", "new-bls-key"} {
+		subCmdRotateBlsKey.MarkFlagRequired(flagName)
+	}
 
 	subCmdDelegate := &cobra.Command{
 		Use:   "delegate",
@@ -651,6 +898,33 @@ Create a new validator"
 Delegating to a validator
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if isStakingBatch() {
+				entries, err := stakingBatchEntries(cmd)
+				if err != nil {
+					return err
+				}
+				if err := checkBatchEntries(entries, true); err != nil {
+					return err
+				}
+				if err := checkBatchCapacity(entries); err != nil {
+					return err
+				}
+				keystorePassphrase, err := getPassphrase()
+				if err != nil {
+					return err
+				}
+				return runStakingBatch(entries, keystorePassphrase, func(entry delegation.Entry, amount *big.Int) (staking.Directive, interface{}) {
+					return staking.DirectiveDelegate, staking.Delegate{
+						address.Parse(entry.Delegator),
+						address.Parse(entry.Validator),
+						amount,
+					}
+				})
+			}
+			if err := requireFlags(cmd, []string{"delegator-addr", "validator-addr", "amount"}); err != nil {
+				return err
+			}
+
 			networkHandler, err := handlerForShard(0, node)
 			if err != nil {
 				return err
@@ -703,12 +977,11 @@ Delegating to a validator
 	subCmdDelegate.Flags().StringVar(&inputNonce, "nonce", "", "set nonce for transaction")
 	subCmdDelegate.Flags().StringVar(&targetChain, "chain-id", "", "what chain ID to target")
 	subCmdDelegate.Flags().Uint32Var(&timeout, "timeout", defaultTimeout, "set timeout in seconds. Set to 0 to not wait for tx confirm")
+	subCmdDelegate.Flags().Uint32Var(&confirmations, "confirmations", 1, confirmationsUsage)
 	subCmdDelegate.Flags().BoolVar(&userProvidesPassphrase, "passphrase", false, ppPrompt)
 	subCmdDelegate.Flags().StringVar(&passphraseFilePath, "passphrase-file", "", "path to a file containing the passphrase")
 
-	for _, flagName := range [...]string{"delegator-addr", "validator-addr", "amount"} {
-		subCmdDelegate.MarkFlagRequired(flagName)
-	}
+	addBatchFlags(subCmdDelegate, true)
 
 	subCmdUnDelegate := &cobra.Command{
 		Use:   "undelegate",
@@ -718,6 +991,30 @@ Delegating to a validator
  Removing delegation responsibility
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if isStakingBatch() {
+				entries, err := stakingBatchEntries(cmd)
+				if err != nil {
+					return err
+				}
+				if err := checkBatchEntries(entries, true); err != nil {
+					return err
+				}
+				keystorePassphrase, err := getPassphrase()
+				if err != nil {
+					return err
+				}
+				return runStakingBatch(entries, keystorePassphrase, func(entry delegation.Entry, amount *big.Int) (staking.Directive, interface{}) {
+					return staking.DirectiveUndelegate, staking.Undelegate{
+						address.Parse(entry.Delegator),
+						address.Parse(entry.Validator),
+						amount,
+					}
+				})
+			}
+			if err := requireFlags(cmd, []string{"delegator-addr", "validator-addr", "amount"}); err != nil {
+				return err
+			}
+
 			networkHandler, err := handlerForShard(0, node)
 			if err != nil {
 				return err
@@ -770,12 +1067,11 @@ Delegating to a validator
 	subCmdUnDelegate.Flags().StringVar(&inputNonce, "nonce", "", "set nonce for transaction")
 	subCmdUnDelegate.Flags().StringVar(&targetChain, "chain-id", "", "what chain ID to target")
 	subCmdUnDelegate.Flags().Uint32Var(&timeout, "timeout", defaultTimeout, "set timeout in seconds. Set to 0 to not wait for tx confirm")
+	subCmdUnDelegate.Flags().Uint32Var(&confirmations, "confirmations", 1, confirmationsUsage)
 	subCmdUnDelegate.Flags().BoolVar(&userProvidesPassphrase, "passphrase", false, ppPrompt)
 	subCmdUnDelegate.Flags().StringVar(&passphraseFilePath, "passphrase-file", "", "path to a file containing the passphrase")
 
-	for _, flagName := range [...]string{"delegator-addr", "validator-addr", "amount"} {
-		subCmdUnDelegate.MarkFlagRequired(flagName)
-	}
+	addBatchFlags(subCmdUnDelegate, true)
 
 	subCmdCollectRewards := &cobra.Command{
 		Use:   "collect-rewards",
@@ -785,6 +1081,28 @@ Delegating to a validator
 Collect token rewards
 `,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if isStakingBatch() {
+				entries, err := stakingBatchEntries(cmd)
+				if err != nil {
+					return err
+				}
+				if err := checkBatchEntries(entries, false); err != nil {
+					return err
+				}
+				keystorePassphrase, err := getPassphrase()
+				if err != nil {
+					return err
+				}
+				return runStakingBatch(entries, keystorePassphrase, func(entry delegation.Entry, _ *big.Int) (staking.Directive, interface{}) {
+					return staking.DirectiveCollectRewards, staking.CollectRewards{
+						address.Parse(entry.Delegator),
+					}
+				})
+			}
+			if err := requireFlags(cmd, []string{"delegator-addr"}); err != nil {
+				return err
+			}
+
 			networkHandler, err := handlerForShard(0, node)
 			if err != nil {
 				return err
@@ -825,19 +1143,22 @@ Collect token rewards
 	subCmdCollectRewards.Flags().StringVar(&inputNonce, "nonce", "", "set nonce for tx")
 	subCmdCollectRewards.Flags().StringVar(&targetChain, "chain-id", "", "what chain ID to target")
 	subCmdCollectRewards.Flags().Uint32Var(&timeout, "timeout", defaultTimeout, "set timeout in seconds. Set to 0 to not wait for tx confirm")
+	subCmdCollectRewards.Flags().Uint32Var(&confirmations, "confirmations", 1, confirmationsUsage)
 	subCmdCollectRewards.Flags().BoolVar(&userProvidesPassphrase, "passphrase", false, ppPrompt)
 	subCmdCollectRewards.Flags().StringVar(&passphraseFilePath, "passphrase-file", "", "path to a file containing the passphrase")
 
-	for _, flagName := range [...]string{"delegator-addr"} {
-		subCmdCollectRewards.MarkFlagRequired(flagName)
-	}
+	addBatchFlags(subCmdCollectRewards, false)
 
 	return []*cobra.Command{
 		subCmdNewValidator,
 		subCmdEditValidator,
+		subCmdRotateBlsKey,
 		subCmdDelegate,
 		subCmdUnDelegate,
 		subCmdCollectRewards,
+		rebalanceCommand(),
+		autoCompoundCommand(),
+		simulateElectionCommand(),
 	}
 }
 
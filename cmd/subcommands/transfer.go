@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -23,7 +24,10 @@ import (
 	"github.com/spf13/cobra"
 )
 
-const defaultTimeout = 40
+const (
+	defaultTimeout     = 40
+	confirmationsUsage = "number of blocks, including its own, a transaction must be buried under to count as confirmed"
+)
 
 var (
 	fromAddress       itcAddress
@@ -35,12 +39,15 @@ var (
 	chainName         chainIDWrapper
 	dryRun            bool
 	offlineSign       bool
+	simulateTx        bool
 	trueNonce         bool
 	inputNonce        string
 	gasPrice          string
 	gasLimit          string
+	inputData         string
 	transferFileFlags []transferFlags
 	timeout           uint32
+	confirmations     uint32
 	timeFormat        = "2006-01-02 15:04:05.000000"
 )
 
@@ -64,10 +71,20 @@ type transferFlags struct {
 	InputNonce       *string `json:"nonce"`
 	GasPrice         *string `json:"gas-price"`
 	GasLimit         *string `json:"gas-limit"`
+	InputData        *string `json:"data"`
 	StopOnError      bool    `json:"stop-on-error"`
 	TrueNonce        bool    `json:"true-nonce"`
 }
 
+// parseInputData decodes the hex encoded input data of a transaction
+func parseInputData(data string) ([]byte, error) {
+	decoded, err := hex.DecodeString(strings.TrimPrefix(data, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("data %w", err)
+	}
+	return decoded, nil
+}
+
 func handlerForShard(senderShard uint32, node string) (*rpc.HTTPMessenger, error) {
 	if checkNodeInput(node) {
 		return rpc.NewHTTPHandler(node), nil
@@ -117,6 +134,9 @@ func handlerForTransaction(txLog *transactionLog) error {
 		if handlerForError(txLog, err) != nil {
 			return err
 		}
+		if err := verifyCustomChain(node); handlerForError(txLog, err) != nil {
+			return err
+		}
 	}
 
 	var ctrlr *transaction.Controller
@@ -150,9 +170,14 @@ func handlerForTransaction(txLog *transactionLog) error {
 		return gasErr
 	}
 
+	data, err := parseInputData(inputData)
+	if handlerForError(txLog, err) != nil {
+		return err
+	}
+
 	var gLimit uint64
 	if gasLimit == "" {
-		gLimit, err = core.IntrinsicGas([]byte(""), false, true, true, false)
+		gLimit, err = core.IntrinsicGas(data, false, true, true, false)
 		if handlerForError(txLog, err) != nil {
 			return err
 		}
@@ -177,7 +202,7 @@ func handlerForTransaction(txLog *transactionLog) error {
 		&addr,
 		fromShardID, toShardID,
 		amt, gPrice,
-		[]byte{},
+		data,
 	)
 
 	if dryRun {
@@ -262,6 +287,11 @@ func handlerForBulkTransactions(txLog *transactionLog, index int) error {
 	} else {
 		gasLimit = "" // Reset to default for subsequent transactions
 	}
+	if txnFlags.InputData != nil {
+		inputData = *txnFlags.InputData
+	} else {
+		inputData = "" // Reset to default for subsequent transactions
+	}
 	trueNonce = txnFlags.TrueNonce
 
 	return handlerForTransaction(txLog)
@@ -274,11 +304,15 @@ func opts(ctlr *transaction.Controller) {
 	if offlineSign {
 		ctlr.Behavior.OfflineSign = true
 	}
+	if simulateTx {
+		ctlr.Behavior.Simulate = true
+	}
 	if useLedgerWallet {
 		ctlr.Behavior.SigningImpl = transaction.Ledger
 	}
 	if timeout > 0 {
 		ctlr.Behavior.ConfirmationWaitTime = timeout
+		ctlr.Behavior.Confirmations = confirmations
 	}
 }
 
@@ -411,11 +445,14 @@ Create a transaction, sign it, and send off to the intelchain blockchain
 	cmdTransfer.Flags().StringVar(&amount, "amount", "0", "amount to send (ITC)")
 	cmdTransfer.Flags().StringVar(&gasPrice, "gas-price", "100", "gas price to pay (TICKS)")
 	cmdTransfer.Flags().StringVar(&gasLimit, "gas-limit", "", "gas limit")
+	cmdTransfer.Flags().StringVar(&inputData, "data", "", "hex encoded input data for the transaction")
+	cmdTransfer.Flags().BoolVar(&simulateTx, "simulate", false, "run transactions with input data through a call first and refuse to send if they would fail")
 	cmdTransfer.Flags().StringVar(&inputNonce, "nonce", "", "set nonce for tx")
 	cmdTransfer.Flags().Uint32Var(&fromShardID, "from-shard", 0, "source shard id")
 	cmdTransfer.Flags().Uint32Var(&toShardID, "to-shard", 0, "target shard id")
 	cmdTransfer.Flags().StringVar(&targetChain, "chain-id", "", "what chain ID to target")
 	cmdTransfer.Flags().Uint32Var(&timeout, "timeout", defaultTimeout, "set timeout in seconds. Set to 0 to not wait for confirm")
+	cmdTransfer.Flags().Uint32Var(&confirmations, "confirmations", 1, confirmationsUsage)
 	cmdTransfer.Flags().BoolVar(&userProvidesPassphrase, "passphrase", false, ppPrompt)
 	cmdTransfer.Flags().StringVar(&passphraseFilePath, "passphrase-file", "", "path to a file containing the passphrase")
 
@@ -501,5 +538,7 @@ Send a offline signed to the intelchain blockchain
 	}
 
 	cmdOfflineSignTransfer.Flags().Uint32Var(&fromShardID, "from-shard", 0, "source shard id")
+	cmdOfflineSignTransfer.Flags().Uint32Var(&timeout, "timeout", defaultTimeout, "set timeout in seconds. Set to 0 to not wait for confirm")
+	cmdOfflineSignTransfer.Flags().Uint32Var(&confirmations, "confirmations", 1, confirmationsUsage)
 	RootCmd.AddCommand(cmdOfflineSignTransfer)
 }
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/intelchain-itc/itc-sdk/pkg/doctor"
+	"github.com/intelchain-itc/itc-sdk/pkg/sharding"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	doctorMaxLatency   time.Duration
+	doctorMaxBlockAge  time.Duration
+	doctorMaxHeightLag uint64
+)
+
+// doctorEndpoints are the endpoints of every shard and --node itself when
+// the sharding structure does not route through it. Without a sharding
+// structure only --node is examined and the error is returned with it.
+func doctorEndpoints() ([]doctor.Endpoint, error) {
+	endpoints := []doctor.Endpoint{}
+	routes, err := sharding.Structure(node)
+	if err != nil {
+		return append(endpoints, doctor.Endpoint{Name: "node", URL: node, ShardID: -1}), err
+	}
+	routed := false
+	for _, route := range routes {
+		endpoints = append(endpoints, doctor.Endpoint{
+			Name: fmt.Sprintf("shard-%d", route.ShardID), URL: route.HTTP, ShardID: route.ShardID,
+		})
+		routed = routed || route.HTTP == node
+	}
+	if !routed {
+		endpoints = append(endpoints, doctor.Endpoint{Name: "node", URL: node, ShardID: -1})
+	}
+	return endpoints, nil
+}
+
+func doctorCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "check the node, every shard endpoint and the CLI configuration",
+		Args:  cobra.ExactArgs(0),
+		Long: `
+Probe --node and every shard endpoint of its sharding structure for
+reachability, latency, latest block and its age, sync status, peer count,
+chain ID, network and protocol version, then flag what looks wrong: a chain
+ID other than the one the CLI signs for, an endpoint serving another shard
+than it is routed for, stale shards, endpoints lagging behind the others of
+their shard by more than --max-height-lag blocks, missing peers and so on. Exits with an
+error when a problem would break transactions. The report is printed in
+--output when it is given, as rows of endpoints for tables and CSV.
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			expect := doctor.Expect{
+				MaxLatency: doctorMaxLatency, MaxBlockAge: doctorMaxBlockAge, MaxHeightLag: doctorMaxHeightLag,
+			}
+			if chainName.chainID != nil {
+				expect.ChainID, expect.Network = chainName.chainID.Value, chainName.chainID.Name
+			}
+			endpoints, structureErr := doctorEndpoints()
+			report := doctor.Examine(endpoints, expect)
+			if structureErr != nil {
+				report.Findings = append(report.Findings, doctor.Finding{
+					Severity: doctor.SeverityError,
+					Endpoint: "node",
+					Message:  fmt.Sprintf("could not fetch the sharding structure, only --node was examined: %s", structureErr),
+				})
+			}
+			if outputChanged(cmd) {
+				if err := printValue(report, doctorView); err != nil {
+					return err
+				}
+			} else {
+				report.WriteTo(os.Stdout)
+			}
+			if !report.Healthy() {
+				return errors.New("the doctor found problems")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&targetChain, "chain-id", "", "chain ID the CLI should sign for, checked against the nodes")
+	cmd.Flags().DurationVar(&doctorMaxLatency, "max-latency", 2*time.Second, "flag endpoints answering slower than this")
+	cmd.Flags().DurationVar(&doctorMaxBlockAge, "max-block-age", time.Minute, "flag shards whose latest block is older than this")
+	cmd.Flags().Uint64Var(&doctorMaxHeightLag, "max-height-lag", 5, "flag endpoints this many blocks behind the highest of their shard")
+	return cmd
+}
@@ -138,5 +138,6 @@ func init() {
 		},
 	}}...)
 
+	cmdUtilities.AddCommand(doctorCommand())
 	RootCmd.AddCommand(cmdUtilities)
 }
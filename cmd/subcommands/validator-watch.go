@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/intelchain-itc/itc-sdk/pkg/sharding"
+	"github.com/intelchain-itc/itc-sdk/pkg/validator"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	watchOptions      validator.WatchOptions
+	watchEvery        string
+	watchPollInterval time.Duration
+	watchExec         string
+	watchWebhooks     []string
+	watchOnce         bool
+)
+
+// observeValidator polls node for everything a watch looks at
+func observeValidator(node, addr string, header *validator.Header) (validator.Observation, error) {
+	obs := validator.Observation{Epoch: header.Epoch, Time: time.Now().UTC()}
+	info, err := validator.GetInformation(node, addr)
+	if err != nil {
+		return obs, fmt.Errorf("validator address not found: %s", addr)
+	}
+	obs.Info = info
+	elected, err := validator.ElectedAddresses(node)
+	if err != nil {
+		return obs, err
+	}
+	for _, a := range elected {
+		if a == addr {
+			obs.Elected = true
+		}
+	}
+	if obs.Failures, err = validator.StakingFailures(node, addr); err != nil {
+		return obs, err
+	}
+	return obs, nil
+}
+
+func validatorWatchCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "watch <address>",
+		Short:   "alert when a validator loses its election or stops signing",
+		Args:    cobra.ExactArgs(1),
+		PreRunE: validateAddress,
+		Long: `
+Poll a validator every block or every epoch and raise alerts when it is not
+elected, its signing rate of the epoch drops below --min-signing, slot keys
+are missing from the committee, its commission changes, its delegation nears
+max-total-delegation, or the node rejects one of its staking transactions.
+Conditions alert when they start and again, resolved, when they clear. Alerts are printed as JSON lines and also sent to
+--alert-exec and --alert-webhook. With --once a failed poll is returned as
+the error of the command.
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if watchEvery != "block" && watchEvery != "epoch" {
+				return fmt.Errorf("--every must be block or epoch, got %s", watchEvery)
+			}
+			sinks := []validator.Sink{validator.WriterSink{W: os.Stdout}}
+			if watchExec != "" {
+				sinks = append(sinks, validator.ExecSink{Command: watchExec})
+			}
+			for _, url := range watchWebhooks {
+				sinks = append(sinks, validator.WebhookSink{URL: url, Timeout: 10 * time.Second})
+			}
+			routes, err := sharding.Structure(node)
+			if err != nil {
+				return err
+			}
+			beaconNode := beaconChainNode(routes)
+			watcher := validator.NewWatcher(addr.address, watchOptions)
+
+			var last *validator.Header
+			for {
+				header, err := validator.LatestHeader(beaconNode)
+				switch {
+				case err != nil:
+					err = errors.Wrap(err, "could not fetch the latest header")
+					if watchOnce {
+						return err
+					}
+					fmt.Fprintln(os.Stderr, err)
+				case last == nil ||
+					(watchEvery == "block" && header.Number != last.Number) ||
+					(watchEvery == "epoch" && header.Epoch != last.Epoch):
+					obs, err := observeValidator(beaconNode, addr.address, header)
+					if err != nil {
+						err = errors.Wrapf(err, "could not observe %s", addr.address)
+						if watchOnce {
+							return err
+						}
+						fmt.Fprintln(os.Stderr, err)
+						break
+					}
+					last = header
+					for _, alert := range watcher.Check(obs) {
+						for _, sink := range sinks {
+							if err := sink.Send(alert); err != nil {
+								fmt.Fprintln(os.Stderr, errors.Wrapf(err, "could not send %s alert", alert.Kind))
+							}
+						}
+					}
+				}
+				if watchOnce {
+					return nil
+				}
+				time.Sleep(watchPollInterval)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&watchEvery, "every", "block", "check on every new block or every new epoch")
+	cmd.Flags().DurationVar(&watchPollInterval, "poll-interval", 5*time.Second, "how often to look for a new block")
+	cmd.Flags().Float64Var(&watchOptions.MinSigning, "min-signing", 0.9, "lowest signing rate of the epoch not alerted on, as a fraction")
+	cmd.Flags().Float64Var(&watchOptions.DelegationFull, "delegation-full", 0.95, "share of max-total-delegation alerted on, as a fraction")
+	cmd.Flags().StringVar(&watchExec, "alert-exec", "", "shell command run per alert with the alert JSON on its standard input")
+	cmd.Flags().StringSliceVar(&watchWebhooks, "alert-webhook", []string{}, "URLs the alert JSON is posted to")
+	cmd.Flags().BoolVar(&watchOnce, "once", false, "check once and exit")
+	return cmd
+}
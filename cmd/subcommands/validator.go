@@ -2,13 +2,27 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"strconv"
+	"strings"
+	"text/tabwriter"
 
+	"github.com/intelchain-itc/itc-sdk/pkg/delegation"
 	"github.com/intelchain-itc/itc-sdk/pkg/rpc"
+	"github.com/intelchain-itc/itc-sdk/pkg/sharding"
+	"github.com/intelchain-itc/itc-sdk/pkg/validator"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
+var (
+	rankFilter      validator.RankFilter
+	rankMinHeadroom string
+	rankSortBy      string
+	rankReverse     bool
+	rankLimit       int
+)
+
 var (
 	validatorSubCmds = []*cobra.Command{{
 		Use:   "elected",
@@ -72,3 +86,73 @@ var (
 	},
 	}
 )
+
+func validatorRankCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rank",
+		Short: "compare validators by uptime, commission and capacity",
+		Args:  cobra.ExactArgs(0),
+		Long: fmt.Sprintf(`
+Rank every validator by what a delegator looks at: lifetime and current epoch
+uptime from signed over to-sign blocks, commission rate and its max change
+rate, total delegation against max-total-delegation, election status and
+effective stake. Filter with the --min-* and --max-* flags and sort by one of
+%s, best first. Prints a table, or the rankings in --output when
+it is given, such as --output csv for a spreadsheet.
+`, strings.Join(validator.RankSortKeys, ", ")),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			filter := rankFilter
+			if rankMinHeadroom != "" {
+				headroom, err := delegation.ParseITC(rankMinHeadroom)
+				if err != nil {
+					return err
+				}
+				filter.MinHeadroom = headroom
+			}
+			routes, err := sharding.Structure(node)
+			if err != nil {
+				return err
+			}
+			infos, err := validator.AllInformation(beaconChainNode(routes))
+			if err != nil {
+				return err
+			}
+			rankings := validator.Rank(infos, filter)
+			if err := validator.SortRankings(rankings, rankSortBy, rankReverse); err != nil {
+				return err
+			}
+			if rankLimit > 0 && len(rankings) > rankLimit {
+				rankings = rankings[:rankLimit]
+			}
+
+			if outputChanged(cmd) {
+				records := []map[string]string{}
+				for _, r := range rankings {
+					record := map[string]string{}
+					for i, field := range r.Row() {
+						record[validator.RankHeader[i]] = field
+					}
+					records = append(records, record)
+				}
+				return printValue(records, headerView(validator.RankHeader))
+			}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, strings.ToUpper(strings.Join(validator.RankHeader, "\t")))
+			for _, r := range rankings {
+				fmt.Fprintln(w, strings.Join(r.Row(), "\t"))
+			}
+			return w.Flush()
+		},
+	}
+
+	cmd.Flags().Float64Var(&rankFilter.MinUptime, "min-uptime", 0, "lowest lifetime uptime to keep, as a fraction such as 0.95")
+	cmd.Flags().Float64Var(&rankFilter.MaxRate, "max-rate", 0, "highest commission rate to keep, as a fraction")
+	cmd.Flags().Float64Var(&rankFilter.MaxChangeRate, "max-change-rate", 0, "highest commission max change rate to keep, as a fraction")
+	cmd.Flags().StringVar(&rankMinHeadroom, "min-headroom", "", "least room left under max-total-delegation to keep, in ITC")
+	cmd.Flags().BoolVar(&rankFilter.ElectedOnly, "elected", false, "keep only validators in the current committee")
+	cmd.Flags().BoolVar(&rankFilter.ActiveOnly, "active", false, "keep only active validators")
+	cmd.Flags().StringVar(&rankSortBy, "sort", "uptime", "sort key, one of "+strings.Join(validator.RankSortKeys, ", "))
+	cmd.Flags().BoolVar(&rankReverse, "reverse", false, "sort worst first")
+	cmd.Flags().IntVar(&rankLimit, "limit", 0, "print only the first validators, 0 prints all")
+	return cmd
+}
@@ -1,11 +1,17 @@
 package account
 
 import (
+	"os"
 	"testing"
 
 	"github.com/intelchain-itc/itc-sdk/pkg/store"
 )
 
+func TestMain(m *testing.M) {
+	store.SetBackend(store.NewMemoryBackend())
+	os.Exit(m.Run())
+}
+
 func TestAccountGetsRemoved(t *testing.T) {
 	tests := []struct {
 		Name     string
@@ -18,6 +18,10 @@ type Creation struct {
 	Mnemonic        string
 	HdAccountNumber *uint32
 	HdIndexNumber   *uint32
+	// HdPath is an arbitrary BIP32 path, overriding the account and index numbers
+	HdPath string
+	// Bip39Passphrase is the optional passphrase extending the mnemonic
+	Bip39Passphrase string
 }
 
 func New() string {
@@ -28,17 +32,33 @@ func IsValidPassphrase(pass string) bool {
 	return true
 }
 
+// hdPath returns the BIP32 path of the candidate, defaulting to the first
+// key of the first intelchain account
+func (candidate *Creation) hdPath() string {
+	if candidate.HdPath != "" {
+		return candidate.HdPath
+	}
+	var account, index uint32
+	if candidate.HdAccountNumber != nil {
+		account = *candidate.HdAccountNumber
+	}
+	if candidate.HdIndexNumber != nil {
+		index = *candidate.HdIndexNumber
+	}
+	return keys.HDPath(keys.IntelchainCoinType, account, index)
+}
+
 // CreateNewLocalAccount assumes all the inputs are valid, legitmate
 func CreateNewLocalAccount(candidate *Creation) error {
-	ks := store.FromAccountName(candidate.Name)
 	if candidate.Mnemonic == "" {
 		candidate.Mnemonic = mnemonic.Generate()
 	}
-	// Hardcoded index of 0 here.
-	private, _ := keys.FromMnemonicSeedAndPassphrase(candidate.Mnemonic, 0)
-	_, err := ks.ImportECDSA(private.ToECDSA(), candidate.Passphrase)
+	private, _, err := keys.FromMnemonicPath(candidate.Mnemonic, candidate.Bip39Passphrase, candidate.hdPath())
 	if err != nil {
 		return err
 	}
+	if _, err := store.ImportECDSA(candidate.Name, private.ToECDSA(), candidate.Passphrase); err != nil {
+		return err
+	}
 	return nil
 }
@@ -0,0 +1,125 @@
+package account
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/intelchain-itc/itc-sdk/pkg/address"
+	"github.com/intelchain-itc/itc-sdk/pkg/common"
+	"github.com/intelchain-itc/itc-sdk/pkg/keys"
+	"github.com/intelchain-itc/itc-sdk/pkg/rpc"
+	"github.com/intelchain-itc/itc-sdk/pkg/sharding"
+)
+
+const (
+	// DefaultGapLimit is the number of consecutive unused indices after which
+	// discovery stops, as suggested by BIP44
+	DefaultGapLimit = 20
+)
+
+// HDWallet selects the keys of an HD account derived from a mnemonic
+type HDWallet struct {
+	Mnemonic   string
+	Passphrase string
+	CoinType   uint32
+	Account    uint32
+}
+
+// Derived is an address derived from a mnemonic at a BIP32 path
+type Derived struct {
+	Path    string `json:"path"`
+	Index   uint32 `json:"index"`
+	Address string `json:"address"`
+}
+
+// UsageCheck reports whether an address has been used on chain
+type UsageCheck func(address string) (bool, error)
+
+// AddressFromPath returns the itc address derived from the mnemonic and its
+// bip39 passphrase at the BIP32 path
+func AddressFromPath(m, passphrase, path string) (string, error) {
+	private, _, err := keys.FromMnemonicPath(m, passphrase, path)
+	if err != nil {
+		return "", err
+	}
+	return address.ToBech32(crypto.PubkeyToAddress(private.ToECDSA().PublicKey)), nil
+}
+
+// Derive returns the address of the wallet at index
+func (w HDWallet) Derive(index uint32) (Derived, error) {
+	path := keys.HDPath(w.CoinType, w.Account, index)
+	addr, err := AddressFromPath(w.Mnemonic, w.Passphrase, path)
+	if err != nil {
+		return Derived{}, err
+	}
+	return Derived{path, index, addr}, nil
+}
+
+// DeriveRange derives count consecutive addresses of the wallet, starting at
+// index
+func DeriveRange(w HDWallet, index, count uint32) ([]Derived, error) {
+	derived := make([]Derived, 0, count)
+	for i := index; i < index+count; i++ {
+		d, err := w.Derive(i)
+		if err != nil {
+			return nil, err
+		}
+		derived = append(derived, d)
+	}
+	return derived, nil
+}
+
+// Discover scans the indices of the wallet in order and returns the
+// addresses that are in use, stopping after gapLimit consecutive unused ones
+func Discover(w HDWallet, gapLimit uint32, used UsageCheck) ([]Derived, error) {
+	found := []Derived{}
+	for index, gap := uint32(0), uint32(0); gap < gapLimit; index++ {
+		d, err := w.Derive(index)
+		if err != nil {
+			return nil, err
+		}
+		isUsed, err := used(d.Address)
+		if err != nil {
+			return nil, err
+		}
+		if !isUsed {
+			gap++
+			continue
+		}
+		gap = 0
+		found = append(found, d)
+	}
+	return found, nil
+}
+
+// ShardUsageCheck returns a UsageCheck that treats an address as used when it
+// has a non-zero balance or nonce on any shard of the network node belongs to
+func ShardUsageCheck(node string) (UsageCheck, error) {
+	shards, err := sharding.Structure(node)
+	if err != nil {
+		return nil, err
+	}
+	return func(address string) (bool, error) {
+		params := []interface{}{address, "latest"}
+		for _, shard := range shards {
+			for _, method := range []string{rpc.Method.GetBalance, rpc.Method.GetTransactionCount} {
+				var result json.RawMessage
+				if err := rpc.RequestResult(method, shard.HTTP, params, &result); err != nil {
+					return false, err
+				}
+				quantity, ok := common.Quantity(result)
+				if !ok {
+					return false, fmt.Errorf("could not parse %s %s of %s", method, result, address)
+				}
+				if quantity.Sign() != 0 {
+					if common.DebugRPC {
+						fmt.Printf("NOTE: %s has activity on shard %d\n", address, shard.ShardID)
+					}
+					return true, nil
+				}
+			}
+		}
+		return false, nil
+	}, nil
+}
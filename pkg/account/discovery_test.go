@@ -0,0 +1,78 @@
+package account
+
+import (
+	"testing"
+
+	"github.com/intelchain-itc/itc-sdk/pkg/address"
+	"github.com/intelchain-itc/itc-sdk/pkg/keys"
+)
+
+var testWallet = HDWallet{
+	Mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+	CoinType: keys.IntelchainCoinType,
+}
+
+func TestDiscoverStopsAtGapLimit(t *testing.T) {
+	tests := []struct {
+		used     []uint32
+		gapLimit uint32
+		expected []uint32
+	}{
+		{[]uint32{}, 3, []uint32{}},
+		{[]uint32{0, 1}, 3, []uint32{0, 1}},
+		{[]uint32{0, 3}, 3, []uint32{0, 3}},
+		{[]uint32{0, 4}, 3, []uint32{0}},
+		{[]uint32{2, 5, 8}, 3, []uint32{2, 5, 8}},
+	}
+
+	for _, test := range tests {
+		usedAddresses := map[string]bool{}
+		derived, _ := DeriveRange(testWallet, 0, 10)
+		for _, d := range derived {
+			for _, index := range test.used {
+				if d.Index == index {
+					usedAddresses[d.Address] = true
+				}
+			}
+		}
+		found, err := Discover(testWallet, test.gapLimit, func(address string) (bool, error) {
+			return usedAddresses[address], nil
+		})
+		if err != nil {
+			t.Errorf("Discover(%v, %d) failed %v", test.used, test.gapLimit, err)
+			continue
+		}
+		indices := []uint32{}
+		for _, d := range found {
+			indices = append(indices, d.Index)
+		}
+		if len(indices) != len(test.expected) {
+			t.Errorf("Discover(%v, %d) returned %v, expected %v", test.used, test.gapLimit, indices, test.expected)
+			continue
+		}
+		for i := range indices {
+			if indices[i] != test.expected[i] {
+				t.Errorf("Discover(%v, %d) returned %v, expected %v", test.used, test.gapLimit, indices, test.expected)
+				break
+			}
+		}
+	}
+}
+
+func TestDeriveRange(t *testing.T) {
+	w := testWallet
+	w.CoinType = keys.EthereumCoinType
+	derived, err := DeriveRange(w, 0, 2)
+	if err != nil {
+		t.Errorf("DeriveRange failed %v", err)
+	}
+	expected := []string{
+		address.ToBech32(address.Parse("0x9858EfFD232B4033E47d90003D41EC34EcaEda94")),
+		address.ToBech32(address.Parse("0x6Fac4D18c912343BF86fa7049364Dd4E424Ab9C0")),
+	}
+	for i, d := range derived {
+		if d.Address != expected[i] {
+			t.Errorf("DeriveRange index %d returned %s, expected %s", d.Index, d.Address, expected[i])
+		}
+	}
+}
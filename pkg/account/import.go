@@ -10,7 +10,6 @@ import (
 	"strings"
 
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/mitchellh/go-homedir"
 
 	"github.com/btcsuite/btcd/btcec"
 	mapset "github.com/deckarep/golang-set"
@@ -50,8 +49,7 @@ func ImportFromPrivateKey(privateKey, name, passphrase string) (string, error) {
 		return "", fmt.Errorf("address %s already exists", itcAddress)
 	}
 
-	ks := store.FromAccountName(name)
-	_, err = ks.ImportECDSA(sk.ToECDSA(), passphrase)
+	_, err = store.ImportECDSA(name, sk.ToECDSA(), passphrase)
 	return name, err
 }
 
@@ -130,10 +128,7 @@ func ImportKeyStore(keyPath, name, passphrase string) (string, error) {
 	if hasAddress {
 		return "", fmt.Errorf("address %s already exists in keystore", b32)
 	}
-	uDir, _ := homedir.Dir()
-	newPath := filepath.Join(uDir, common.DefaultConfigDirName, common.DefaultConfigAccountAliasesDirName, name, filepath.Base(keyPath))
-	err = writeToFile(newPath, string(keyJSON))
-	if err != nil {
+	if err := store.ImportKeyFile(name, filepath.Base(keyPath), keyJSON); err != nil {
 		return "", err
 	}
 	return name, nil
@@ -0,0 +1,227 @@
+package clock
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/intelchain-itc/itc-sdk/pkg/common"
+	"github.com/intelchain-itc/itc-sdk/pkg/rpc"
+)
+
+// DefaultWindow is how many of the latest blocks the block time is averaged
+// over
+const DefaultWindow = 1000
+
+// Block is when a block was made and in which epoch
+type Block struct {
+	Number uint64    `json:"number"`
+	Epoch  uint64    `json:"epoch"`
+	Time   time.Time `json:"time"`
+}
+
+// Chain is where the clock reads blocks from
+type Chain interface {
+	Latest() (Block, error)
+	Block(number uint64) (Block, error)
+	BlocksPerEpoch() (uint64, error)
+}
+
+// RPCChain reads blocks from a node
+type RPCChain struct {
+	Node string
+}
+
+type rpcBlock struct {
+	Epoch     json.RawMessage `json:"epoch"`
+	Timestamp json.RawMessage `json:"timestamp"`
+}
+
+// Latest fetches the latest block of the node
+func (c RPCChain) Latest() (Block, error) {
+	header := struct {
+		Number   uint64 `json:"blockNumber"`
+		Epoch    uint64 `json:"epoch"`
+		UnixTime int64  `json:"unixtime"`
+	}{}
+	if err := rpc.RequestResult(rpc.Method.GetLatestBlockHeader, c.Node, []interface{}{}, &header); err != nil {
+		return Block{}, err
+	}
+	return Block{header.Number, header.Epoch, time.Unix(header.UnixTime, 0).UTC()}, nil
+}
+
+// Block fetches a block of the node
+func (c RPCChain) Block(number uint64) (Block, error) {
+	block := rpcBlock{}
+	params := []interface{}{fmt.Sprintf("0x%x", number), false}
+	if err := rpc.RequestResult(rpc.Method.GetBlockByNumber, c.Node, params, &block); err != nil {
+		return Block{}, err
+	}
+	epoch, ok := common.Quantity(block.Epoch)
+	if !ok || !epoch.IsUint64() {
+		return Block{}, fmt.Errorf("could not read the epoch of block %d", number)
+	}
+	timestamp, ok := common.Quantity(block.Timestamp)
+	if !ok || !timestamp.IsInt64() {
+		return Block{}, fmt.Errorf("could not read the timestamp of block %d", number)
+	}
+	return Block{number, epoch.Uint64(), time.Unix(timestamp.Int64(), 0).UTC()}, nil
+}
+
+// BlocksPerEpoch fetches the epoch length the node reports in its metadata
+func (c RPCChain) BlocksPerEpoch() (uint64, error) {
+	meta := struct {
+		BlocksPerEpoch uint64 `json:"blocks-per-epoch"`
+	}{}
+	if err := rpc.RequestResult(rpc.Method.GetNodeMetadata, c.Node, []interface{}{}, &meta); err != nil {
+		return 0, err
+	}
+	if meta.BlocksPerEpoch == 0 {
+		return 0, errors.New("node metadata does not report blocks-per-epoch")
+	}
+	return meta.BlocksPerEpoch, nil
+}
+
+// Clock places blocks and epochs of a chain in time from its latest block,
+// the first block of the current epoch and the average block time
+type Clock struct {
+	Latest         Block         `json:"latest"`
+	EpochStart     uint64        `json:"epoch-first-block"`
+	BlocksPerEpoch uint64        `json:"blocks-per-epoch"`
+	BlockTime      time.Duration `json:"block-time"`
+	Window         uint64        `json:"window"`
+}
+
+// New reads the clock of chain, averaging the block time over the last
+// window blocks
+func New(chain Chain, window uint64) (*Clock, error) {
+	if window == 0 {
+		return nil, errors.New("the block time window must be at least one block")
+	}
+	latest, err := chain.Latest()
+	if err != nil {
+		return nil, err
+	}
+	blocksPerEpoch, err := chain.BlocksPerEpoch()
+	if err != nil {
+		return nil, err
+	}
+	if latest.Number < window {
+		window = latest.Number
+	}
+	if window == 0 {
+		return nil, errors.New("the chain has no blocks to measure the block time on")
+	}
+	then, err := chain.Block(latest.Number - window)
+	if err != nil {
+		return nil, err
+	}
+	c := &Clock{
+		Latest:         latest,
+		BlocksPerEpoch: blocksPerEpoch,
+		BlockTime:      latest.Time.Sub(then.Time) / time.Duration(window),
+		Window:         window,
+	}
+
+	// the current epoch began within the last blocks-per-epoch blocks, find
+	// its first block
+	low := uint64(0)
+	if latest.Number >= blocksPerEpoch {
+		low = latest.Number - blocksPerEpoch + 1
+	}
+	high := latest.Number
+	for low < high {
+		middle := low + (high-low)/2
+		block, err := chain.Block(middle)
+		if err != nil {
+			return nil, err
+		}
+		if block.Epoch < latest.Epoch {
+			low = middle + 1
+		} else {
+			high = middle
+		}
+	}
+	c.EpochStart = low
+	return c, nil
+}
+
+// EpochLastBlock is the last block of the current epoch
+func (c *Clock) EpochLastBlock() uint64 {
+	return c.EpochStart + c.BlocksPerEpoch - 1
+}
+
+// BlocksLeft is how many blocks the current epoch has left after the latest
+func (c *Clock) BlocksLeft() uint64 {
+	if c.EpochLastBlock() < c.Latest.Number {
+		return 0
+	}
+	return c.EpochLastBlock() - c.Latest.Number
+}
+
+// EpochLength is the estimated duration of an epoch
+func (c *Clock) EpochLength() time.Duration {
+	return c.BlockTime * time.Duration(c.BlocksPerEpoch)
+}
+
+// BlockTimeOf estimates when block number is made, or was for past blocks
+func (c *Clock) BlockTimeOf(number uint64) time.Time {
+	if number >= c.Latest.Number {
+		return c.Latest.Time.Add(c.BlockTime * time.Duration(number-c.Latest.Number))
+	}
+	return c.Latest.Time.Add(-c.BlockTime * time.Duration(c.Latest.Number-number))
+}
+
+// EpochFirstBlock estimates the first block of a later epoch, or returns the
+// first block of the current one
+func (c *Clock) EpochFirstBlock(epoch uint64) uint64 {
+	if epoch <= c.Latest.Epoch {
+		return c.EpochStart
+	}
+	return c.EpochStart + (epoch-c.Latest.Epoch)*c.BlocksPerEpoch
+}
+
+// EpochTime estimates when a later epoch begins, or when the current one
+// began
+func (c *Clock) EpochTime(epoch uint64) time.Time {
+	return c.BlockTimeOf(c.EpochFirstBlock(epoch))
+}
+
+// BlockAtTime finds the block of chain made closest to t by binary search,
+// the earlier one on a tie
+func BlockAtTime(chain Chain, t time.Time) (Block, error) {
+	latest, err := chain.Latest()
+	if err != nil {
+		return Block{}, err
+	}
+	if !t.Before(latest.Time) {
+		return latest, nil
+	}
+	// the first block made at or after t
+	low, high := uint64(0), latest.Number
+	for low < high {
+		middle := low + (high-low)/2
+		block, err := chain.Block(middle)
+		if err != nil {
+			return Block{}, err
+		}
+		if block.Time.Before(t) {
+			low = middle + 1
+		} else {
+			high = middle
+		}
+	}
+	after, err := chain.Block(low)
+	if err != nil || low == 0 {
+		return after, err
+	}
+	before, err := chain.Block(low - 1)
+	if err != nil {
+		return Block{}, err
+	}
+	if t.Sub(before.Time) <= after.Time.Sub(t) {
+		return before, nil
+	}
+	return after, nil
+}
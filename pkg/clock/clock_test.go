@@ -0,0 +1,84 @@
+package clock
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+var genesis = time.Unix(1700000000, 0).UTC()
+
+// fakeChain makes a block every 2 seconds, with epochs of 100 blocks from
+// block 50 on and epoch 0 before
+type fakeChain struct {
+	latest uint64
+}
+
+func (c fakeChain) Block(number uint64) (Block, error) {
+	if number > c.latest {
+		return Block{}, fmt.Errorf("block %d not found", number)
+	}
+	epoch := uint64(0)
+	if number >= 50 {
+		epoch = (number-50)/100 + 1
+	}
+	return Block{number, epoch, genesis.Add(time.Duration(number) * 2 * time.Second)}, nil
+}
+
+func (c fakeChain) Latest() (Block, error) {
+	return c.Block(c.latest)
+}
+
+func (c fakeChain) BlocksPerEpoch() (uint64, error) {
+	return 100, nil
+}
+
+func TestClock(t *testing.T) {
+	c, err := New(fakeChain{latest: 420}, DefaultWindow)
+	if err != nil {
+		t.Fatalf("New returned error %v", err)
+	}
+	if c.Latest.Epoch != 4 || c.EpochStart != 350 || c.EpochLastBlock() != 449 || c.BlocksLeft() != 29 {
+		t.Errorf("New returned %+v, expected epoch 4 from block 350 to 449", c)
+	}
+	if c.BlockTime != 2*time.Second || c.Window != 420 || c.EpochLength() != 200*time.Second {
+		t.Errorf("New returned block time %s over %d blocks, expected 2s over 420", c.BlockTime, c.Window)
+	}
+	tests := []struct {
+		epoch    uint64
+		expected time.Time
+	}{
+		{4, genesis.Add(700 * time.Second)},
+		{5, genesis.Add(900 * time.Second)},
+		{7, genesis.Add(1300 * time.Second)},
+	}
+	for _, test := range tests {
+		if result := c.EpochTime(test.epoch); !result.Equal(test.expected) {
+			t.Errorf("EpochTime(%d) returned %s, expected %s", test.epoch, result, test.expected)
+		}
+	}
+	if result := c.BlockTimeOf(500); !result.Equal(genesis.Add(1000 * time.Second)) {
+		t.Errorf("BlockTimeOf(500) returned %s", result)
+	}
+}
+
+func TestBlockAtTime(t *testing.T) {
+	chain := fakeChain{latest: 420}
+	tests := []struct {
+		at       time.Time
+		expected uint64
+	}{
+		{genesis.Add(-time.Hour), 0},
+		{genesis, 0},
+		{genesis.Add(201 * time.Second), 100},
+		{genesis.Add(202 * time.Second), 101},
+		{genesis.Add(203 * time.Second), 101},
+		{genesis.Add(time.Hour), 420},
+	}
+	for _, test := range tests {
+		block, err := BlockAtTime(chain, test.at)
+		if err != nil || block.Number != test.expected {
+			t.Errorf("BlockAtTime(%s) returned %d, %v, expected %d", test.at, block.Number, err, test.expected)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package common
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+)
+
+// AttoPerITC is the number of atto in one ITC
+var AttoPerITC = new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+
+// Quantity reads a number the node sent as a 0x prefixed hex string, a
+// decimal string or a JSON number, exactly
+func Quantity(raw json.RawMessage) (*big.Int, bool) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+			return new(big.Int).SetString(s[2:], 16)
+		}
+		return new(big.Int).SetString(s, 10)
+	}
+	n := new(big.Int)
+	if err := json.Unmarshal(raw, n); err != nil {
+		return nil, false
+	}
+	return n, true
+}
+
+// FormatITC renders an amount in atto as ITC without trailing zeros
+func FormatITC(atto *big.Int) string {
+	if atto == nil {
+		return ""
+	}
+	itc := new(big.Rat).SetFrac(atto, AttoPerITC)
+	if itc.IsInt() {
+		return itc.Num().String()
+	}
+	return strings.TrimRight(itc.FloatString(18), "0")
+}
@@ -0,0 +1,55 @@
+package common
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+)
+
+func TestQuantity(t *testing.T) {
+	tests := []struct {
+		raw      string
+		expected string
+	}{
+		{`"0x10"`, "16"},
+		{`"16"`, "16"},
+		{`"010"`, "10"},
+		{`"0X1f"`, "31"},
+		{`"0b1"`, ""},
+		{`"0o7"`, ""},
+		{`"1_0"`, ""},
+		{`"0x"`, ""},
+		{`16`, "16"},
+		{`1000000000000000000000001`, "1000000000000000000000001"},
+		{`"nope"`, ""},
+		{`true`, ""},
+	}
+	for _, test := range tests {
+		n, ok := Quantity(json.RawMessage(test.raw))
+		result := ""
+		if ok {
+			result = n.String()
+		}
+		if result != test.expected {
+			t.Errorf("Quantity(%s) returned %s, expected %s", test.raw, result, test.expected)
+		}
+	}
+}
+
+func TestFormatITC(t *testing.T) {
+	tests := []struct {
+		atto     string
+		expected string
+	}{
+		{"0", "0"},
+		{"1000000000000000000", "1"},
+		{"1500000000000000000", "1.5"},
+		{"1", "0.000000000000000001"},
+	}
+	for _, test := range tests {
+		atto, _ := new(big.Int).SetString(test.atto, 10)
+		if formatted := FormatITC(atto); formatted != test.expected {
+			t.Errorf("FormatITC(%s) returned %s, expected %s", test.atto, formatted, test.expected)
+		}
+	}
+}
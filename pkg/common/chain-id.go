@@ -7,10 +7,12 @@ import (
 	"strconv"
 )
 
-// ChainID is a wrapper around the human name for a chain and the actual Big.Int used
+// ChainID is a wrapper around the human name for a chain and the actual Big.Int used,
+// EthValue is the chain ID of eth-compatible transactions when it differs
 type ChainID struct {
-	Name  string   `json:"-"`
-	Value *big.Int `json:"chain-as-number"`
+	Name     string   `json:"-"`
+	Value    *big.Int `json:"chain-as-number"`
+	EthValue *big.Int `json:"eth-chain-as-number,omitempty"`
 }
 
 type chainIDList struct {
@@ -23,18 +25,35 @@ type chainIDList struct {
 
 // Chain is an enumeration of the known Chain-IDs
 var Chain = chainIDList{
-	MainNet:    ChainID{"mainnet", big.NewInt(1)},
-	TestNet:    ChainID{"testnet", big.NewInt(2)},
-	PangaeaNet: ChainID{"pangaea", big.NewInt(3)},
-	PartnerNet: ChainID{"partner", big.NewInt(4)},
-	StressNet:  ChainID{"stress", big.NewInt(5)},
+	MainNet:    ChainID{Name: "mainnet", Value: big.NewInt(1)},
+	TestNet:    ChainID{Name: "testnet", Value: big.NewInt(2)},
+	PangaeaNet: ChainID{Name: "pangaea", Value: big.NewInt(3)},
+	PartnerNet: ChainID{Name: "partner", Value: big.NewInt(4)},
+	StressNet:  ChainID{Name: "stress", Value: big.NewInt(5)},
 }
 
 func (c chainIDList) String() string {
 	s, _ := json.MarshalIndent(c, "", "  ")
+	if len(customChains) == 0 {
+		return string(s)
+	}
+	known := map[string]interface{}{}
+	json.Unmarshal(s, &known)
+	for _, chain := range CustomChains() {
+		known[chain.Name] = chain
+	}
+	s, _ = json.MarshalIndent(known, "", "  ")
 	return string(s)
 }
 
+// EthID is the chain ID of eth-compatible transactions on the chain
+func (c ChainID) EthID() *big.Int {
+	if c.EthValue != nil {
+		return c.EthValue
+	}
+	return c.Value
+}
+
 // StringToChainID returns the ChainID wrapper for the given human name of a chain-id
 func StringToChainID(name string) (*ChainID, error) {
 	switch name {
@@ -53,7 +72,15 @@ func StringToChainID(name string) (*ChainID, error) {
 	case "dryrun":
 		return &Chain.MainNet, nil
 	default:
+		if chain, ok := LookupCustomChain(name); ok {
+			return chain.ID(), nil
+		}
 		if chainID, err := strconv.Atoi(name); err == nil && chainID >= 0 {
+			for _, chain := range CustomChains() {
+				if chain.ChainID == uint64(chainID) {
+					return chain.ID(), nil
+				}
+			}
 			return &ChainID{Name: fmt.Sprintf("%d", chainID), Value: big.NewInt(int64(chainID))}, nil
 		}
 		return nil, fmt.Errorf("unknown chain-id: %s", name)
@@ -0,0 +1,90 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+)
+
+// CustomChain is a chain beyond the known Chain-IDs, such as a private devnet
+type CustomChain struct {
+	Name       string         `yaml:"name" json:"name"`
+	ChainID    uint64         `yaml:"chain-id" json:"chain-as-number"`
+	EthChainID uint64         `yaml:"eth-chain-id,omitempty" json:"eth-chain-as-number,omitempty"`
+	ShardCount int            `yaml:"shard-count" json:"shard-count"`
+	Endpoints  map[int]string `yaml:"endpoints,omitempty" json:"endpoints,omitempty"`
+}
+
+var (
+	customChains = map[string]CustomChain{}
+	// names StringToChainID resolves to a known Chain-ID
+	reservedChainNames = []string{"mainnet", "testnet", "pangaea", "devnet", "partner", "stress", "stressnet", "dryrun"}
+)
+
+// ID is the ChainID wrapper of the chain
+func (c CustomChain) ID() *ChainID {
+	id := &ChainID{Name: c.Name, Value: new(big.Int).SetUint64(c.ChainID)}
+	if c.EthChainID != 0 {
+		id.EthValue = new(big.Int).SetUint64(c.EthChainID)
+	}
+	return id
+}
+
+// Validate checks the chain can be registered
+func (c CustomChain) Validate() error {
+	if c.Name == "" {
+		return errors.New("a custom chain needs a name")
+	}
+	if _, err := strconv.Atoi(c.Name); err == nil {
+		return fmt.Errorf("custom chain name %s is a number", c.Name)
+	}
+	for _, reserved := range reservedChainNames {
+		if c.Name == reserved {
+			return fmt.Errorf("custom chain name %s is taken by a known chain", c.Name)
+		}
+	}
+	if c.ChainID == 0 {
+		return fmt.Errorf("custom chain %s needs a chain-id", c.Name)
+	}
+	if c.ShardCount < 1 {
+		return fmt.Errorf("custom chain %s needs at least one shard", c.Name)
+	}
+	for shard := range c.Endpoints {
+		if shard < 0 || shard >= c.ShardCount {
+			return fmt.Errorf("custom chain %s has an endpoint for shard %d of %d shards", c.Name, shard, c.ShardCount)
+		}
+	}
+	return nil
+}
+
+// RegisterChain makes a custom chain known to StringToChainID
+func RegisterChain(c CustomChain) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+	for _, other := range customChains {
+		if other.Name != c.Name && other.ChainID == c.ChainID {
+			return fmt.Errorf("custom chains %s and %s share chain-id %d", other.Name, c.Name, c.ChainID)
+		}
+	}
+	customChains[c.Name] = c
+	return nil
+}
+
+// LookupCustomChain returns the registered custom chain called name
+func LookupCustomChain(name string) (CustomChain, bool) {
+	c, ok := customChains[name]
+	return c, ok
+}
+
+// CustomChains lists the registered custom chains by name
+func CustomChains() []CustomChain {
+	chains := []CustomChain{}
+	for _, c := range customChains {
+		chains = append(chains, c)
+	}
+	sort.Slice(chains, func(i, j int) bool { return chains[i].Name < chains[j].Name })
+	return chains
+}
@@ -0,0 +1,89 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	"github.com/intelchain-itc/itc-sdk/pkg/common"
+	"github.com/intelchain-itc/itc-sdk/pkg/rpc"
+	homedir "github.com/mitchellh/go-homedir"
+	"gopkg.in/yaml.v2"
+)
+
+// ChainsFileName is the name of the custom chain registry in the config
+// directory
+const ChainsFileName = "chains.yaml"
+
+// ChainsPath is the custom chain registry in the config directory of the user
+func ChainsPath() string {
+	uDir, _ := homedir.Dir()
+	return filepath.Join(uDir, common.DefaultConfigDirName, ChainsFileName)
+}
+
+// LoadChains reads the custom chains listed at path, a missing file lists
+// none
+func LoadChains(path string) ([]common.CustomChain, error) {
+	registry := struct {
+		Chains []common.CustomChain `yaml:"chains"`
+	}{}
+	content, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.UnmarshalStrict(content, &registry); err != nil {
+		return nil, fmt.Errorf("could not parse %s: %w", path, err)
+	}
+	return registry.Chains, nil
+}
+
+// RegisterChains registers the custom chains listed at path
+func RegisterChains(path string) error {
+	chains, err := LoadChains(path)
+	if err != nil {
+		return err
+	}
+	for _, chain := range chains {
+		if err := common.RegisterChain(chain); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// VerifyChain checks node serves the custom chain: its chain IDs as the node
+// metadata reports them and its shard count as the sharding structure does
+func VerifyChain(node string, chain common.CustomChain) error {
+	meta := struct {
+		ChainConfig struct {
+			ChainID    *big.Int `json:"chain-id"`
+			EthChainID *big.Int `json:"eth-compatible-chain-id"`
+		} `json:"chain-config"`
+	}{}
+	if err := rpc.RequestResult(rpc.Method.GetNodeMetadata, node, []interface{}{}, &meta); err != nil {
+		return fmt.Errorf("could not verify chain %s: %w", chain.Name, err)
+	}
+	id := chain.ID()
+	if meta.ChainConfig.ChainID == nil || meta.ChainConfig.ChainID.Cmp(id.Value) != 0 {
+		return fmt.Errorf("node is on chain ID %v but chain %s has chain-id %s", meta.ChainConfig.ChainID, chain.Name, id.Value)
+	}
+	if id.EthValue != nil && (meta.ChainConfig.EthChainID == nil || meta.ChainConfig.EthChainID.Cmp(id.EthValue) != 0) {
+		return fmt.Errorf("node is on eth chain ID %v but chain %s has eth-chain-id %s",
+			meta.ChainConfig.EthChainID, chain.Name, id.EthValue)
+	}
+	shards := []struct {
+		ShardID int `json:"shardID"`
+	}{}
+	if err := rpc.RequestResult(rpc.Method.GetShardingStructure, node, []interface{}{}, &shards); err != nil {
+		return fmt.Errorf("could not verify chain %s: %w", chain.Name, err)
+	}
+	if len(shards) != chain.ShardCount {
+		return fmt.Errorf("node has %d shards but chain %s has shard-count %d", len(shards), chain.Name, chain.ShardCount)
+	}
+	return nil
+}
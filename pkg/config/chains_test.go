@@ -0,0 +1,88 @@
+package config
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/intelchain-itc/itc-sdk/pkg/common"
+)
+
+func TestRegisterChains(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ChainsFileName)
+	registry := `chains:
+- name: localnet
+  chain-id: 1337
+  eth-chain-id: 1666700000
+  shard-count: 2
+  endpoints:
+    0: http://10.0.0.1:9500
+    1: http://10.0.0.2:9500
+`
+	if err := ioutil.WriteFile(path, []byte(registry), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := RegisterChains(path); err != nil {
+		t.Fatalf("RegisterChains returned error %v", err)
+	}
+	for _, name := range []string{"localnet", "1337"} {
+		id, err := common.StringToChainID(name)
+		if err != nil || id.Name != "localnet" || id.Value.Uint64() != 1337 || id.EthID().Uint64() != 1666700000 {
+			t.Errorf("StringToChainID(%s) returned %+v, %v, expected localnet", name, id, err)
+		}
+	}
+	if !strings.Contains(common.Chain.String(), `"localnet"`) {
+		t.Errorf("known chains %s do not list localnet", common.Chain.String())
+	}
+
+	invalid := []common.CustomChain{
+		{Name: "mainnet", ChainID: 7, ShardCount: 1},
+		{Name: "other", ChainID: 1337, ShardCount: 1},
+		{Name: "sharded", ChainID: 8, ShardCount: 1, Endpoints: map[int]string{1: "http://10.0.0.2:9500"}},
+		{Name: "42", ChainID: 9, ShardCount: 1},
+	}
+	for _, chain := range invalid {
+		if err := common.RegisterChain(chain); err == nil {
+			t.Errorf("RegisterChain(%+v) returned no error", chain)
+		}
+	}
+}
+
+func TestVerifyChain(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		call := map[string]interface{}{}
+		json.NewDecoder(r.Body).Decode(&call)
+		reply := map[string]interface{}{"jsonrpc": "2.0", "id": call["id"]}
+		switch call["method"] {
+		case "itc_getNodeMetadata":
+			reply["result"] = map[string]interface{}{
+				"chain-config": map[string]interface{}{"chain-id": 1337, "eth-compatible-chain-id": 1666700000},
+			}
+		case "itc_getShardingStructure":
+			reply["result"] = []map[string]interface{}{{"shardID": 0}, {"shardID": 1}}
+		}
+		json.NewEncoder(w).Encode(reply)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		chain    common.CustomChain
+		expected string
+	}{
+		{common.CustomChain{Name: "localnet", ChainID: 1337, EthChainID: 1666700000, ShardCount: 2}, ""},
+		{common.CustomChain{Name: "localnet", ChainID: 1337, ShardCount: 2}, ""},
+		{common.CustomChain{Name: "devnet-2", ChainID: 1338, ShardCount: 2}, "chain ID 1337"},
+		{common.CustomChain{Name: "localnet", ChainID: 1337, EthChainID: 1, ShardCount: 2}, "eth chain ID"},
+		{common.CustomChain{Name: "localnet", ChainID: 1337, ShardCount: 4}, "2 shards"},
+	}
+	for _, test := range tests {
+		err := VerifyChain(server.URL, test.chain)
+		if (test.expected == "" && err != nil) || (test.expected != "" && (err == nil || !strings.Contains(err.Error(), test.expected))) {
+			t.Errorf("VerifyChain(%+v) returned %v, expected %q", test.chain, err, test.expected)
+		}
+	}
+}
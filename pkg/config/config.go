@@ -0,0 +1,193 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/intelchain-itc/itc-sdk/pkg/common"
+	"github.com/intelchain-itc/itc-sdk/pkg/validation"
+	homedir "github.com/mitchellh/go-homedir"
+	"gopkg.in/yaml.v2"
+)
+
+// FileName is the name of the config file in the config directory
+const FileName = "config.yaml"
+
+var (
+	// ErrNoProfile is returned for a profile the config does not have
+	ErrNoProfile = errors.New("no such profile")
+	// ErrProfileExists is returned when creating a profile that exists
+	ErrProfileExists = errors.New("profile already exists")
+)
+
+// Profile is a named set of defaults for a network
+type Profile struct {
+	Nodes     map[int]string    `yaml:"nodes"`
+	ChainID   string            `yaml:"chain-id,omitempty"`
+	RPCPrefix string            `yaml:"rpc-prefix,omitempty"`
+	From      string            `yaml:"from,omitempty"`
+	GasPrice  string            `yaml:"gas-price,omitempty"`
+	GasLimit  string            `yaml:"gas-limit,omitempty"`
+	Rewrites  map[string]string `yaml:"endpoint-rewrites,omitempty"`
+}
+
+// Config is the content of the config file
+type Config struct {
+	DefaultProfile string             `yaml:"default-profile,omitempty"`
+	Profiles       map[string]Profile `yaml:"profiles"`
+}
+
+// DefaultPath is the config file in the config directory of the user
+func DefaultPath() string {
+	uDir, _ := homedir.Dir()
+	return filepath.Join(uDir, common.DefaultConfigDirName, FileName)
+}
+
+// Load reads the config file at path, a missing file is an empty config
+func Load(path string) (*Config, error) {
+	c := &Config{Profiles: map[string]Profile{}}
+	content, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.UnmarshalStrict(content, c); err != nil {
+		return nil, fmt.Errorf("could not parse %s: %w", path, err)
+	}
+	if c.Profiles == nil {
+		c.Profiles = map[string]Profile{}
+	}
+	return c, nil
+}
+
+// Save writes the config to path
+func (c *Config) Save(path string) error {
+	content, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, content, 0600)
+}
+
+// Names lists the profiles in order
+func (c *Config) Names() []string {
+	names := []string{}
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Profile returns the profile called name, or the default profile when name
+// is empty. Without either it returns false.
+func (c *Config) Profile(name string) (Profile, bool, error) {
+	if name == "" {
+		name = c.DefaultProfile
+	}
+	if name == "" {
+		return Profile{}, false, nil
+	}
+	p, ok := c.Profiles[name]
+	if !ok {
+		return Profile{}, false, fmt.Errorf("%w: %s", ErrNoProfile, name)
+	}
+	return p, true, nil
+}
+
+// Add stores a new profile called name
+func (c *Config) Add(name string, p Profile) error {
+	if name == "" {
+		return errors.New("a profile needs a name")
+	}
+	if _, exists := c.Profiles[name]; exists {
+		return fmt.Errorf("%w: %s", ErrProfileExists, name)
+	}
+	c.Profiles[name] = p
+	return nil
+}
+
+// Validate checks the profile, returning every problem found
+func (p Profile) Validate() []error {
+	problems := []error{}
+	if _, ok := p.Nodes[0]; !ok {
+		problems = append(problems, errors.New("nodes needs the node of shard 0"))
+	}
+	shards := []int{}
+	for shard := range p.Nodes {
+		shards = append(shards, shard)
+	}
+	sort.Ints(shards)
+	for _, shard := range shards {
+		if shard < 0 {
+			problems = append(problems, fmt.Errorf("nodes has negative shard %d", shard))
+		}
+		if err := validateURL(p.Nodes[shard]); err != nil {
+			problems = append(problems, fmt.Errorf("node of shard %d: %w", shard, err))
+		}
+	}
+	if p.ChainID != "" {
+		if _, err := common.StringToChainID(p.ChainID); err != nil {
+			problems = append(problems, err)
+		}
+	}
+	if p.RPCPrefix != "" && p.RPCPrefix != "itc" && p.RPCPrefix != "eth" {
+		problems = append(problems, fmt.Errorf("rpc-prefix must be itc or eth, got %s", p.RPCPrefix))
+	}
+	if strings.HasPrefix(p.From, "itc1") || strings.HasPrefix(p.From, "0x") {
+		if err := validation.ValidateAddress(p.From); err != nil {
+			problems = append(problems, fmt.Errorf("from: %w", err))
+		}
+	} else if strings.ContainsAny(p.From, `/\`) {
+		problems = append(problems, fmt.Errorf("from is neither an address nor an account name: %s", p.From))
+	}
+	if p.GasPrice != "" {
+		if price, err := strconv.ParseFloat(p.GasPrice, 64); err != nil || price < 0 {
+			problems = append(problems, fmt.Errorf("gas-price is not a non-negative number: %s", p.GasPrice))
+		}
+	}
+	if p.GasLimit != "" {
+		if _, err := strconv.ParseUint(p.GasLimit, 10, 64); err != nil {
+			problems = append(problems, fmt.Errorf("gas-limit is not a whole number: %s", p.GasLimit))
+		}
+	}
+	from := []string{}
+	for endpoint := range p.Rewrites {
+		from = append(from, endpoint)
+	}
+	sort.Strings(from)
+	for _, endpoint := range from {
+		if err := validateURL(p.Rewrites[endpoint]); err != nil {
+			problems = append(problems, fmt.Errorf("rewrite of %s: %w", endpoint, err))
+		}
+	}
+	return problems
+}
+
+func validateURL(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return err
+	}
+	switch u.Scheme {
+	case "http", "https", "ws", "wss":
+	default:
+		return fmt.Errorf("%s is not an http or ws URL", endpoint)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("%s has no host", endpoint)
+	}
+	return nil
+}
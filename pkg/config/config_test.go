@@ -0,0 +1,64 @@
+package config
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "itc", FileName)
+	c, err := Load(path)
+	if err != nil || len(c.Profiles) != 0 {
+		t.Fatalf("Load of a missing file returned %+v, %v, expected an empty config", c, err)
+	}
+	devnet := Profile{
+		Nodes:     map[int]string{0: "http://10.0.0.1:9500", 1: "http://10.0.0.2:9500"},
+		ChainID:   "4",
+		RPCPrefix: "itc",
+		GasPrice:  "100",
+		Rewrites:  map[string]string{"http://127.0.0.1:9500": "http://10.0.0.1:9500"},
+	}
+	if err := c.Add("devnet", devnet); err != nil {
+		t.Fatalf("Add returned error %v", err)
+	}
+	if err := c.Add("devnet", devnet); !errors.Is(err, ErrProfileExists) {
+		t.Errorf("Add of an existing profile returned %v, expected %v", err, ErrProfileExists)
+	}
+	c.DefaultProfile = "devnet"
+	if err := c.Save(path); err != nil {
+		t.Fatalf("Save returned error %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load returned error %v", err)
+	}
+	p, ok, err := loaded.Profile("")
+	if err != nil || !ok || !reflect.DeepEqual(p, devnet) {
+		t.Errorf("Profile(\"\") returned %+v, %v, %v, expected %+v", p, ok, err, devnet)
+	}
+	if _, _, err := loaded.Profile("mainnet"); !errors.Is(err, ErrNoProfile) {
+		t.Errorf("Profile(mainnet) returned %v, expected %v", err, ErrNoProfile)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		profile  Profile
+		problems int
+	}{
+		{Profile{Nodes: map[int]string{0: "https://api.s0.t.intelchain.network"}, ChainID: "mainnet"}, 0},
+		{Profile{Nodes: map[int]string{0: "http://localhost:9500"}, From: "validator-one", GasLimit: "25000"}, 0},
+		{Profile{Nodes: map[int]string{1: "localhost:9500"}}, 2},
+		{Profile{Nodes: map[int]string{0: "http://localhost:9500"}, ChainID: "moonnet", RPCPrefix: "hmy"}, 2},
+		{Profile{Nodes: map[int]string{0: "http://localhost:9500"}, From: "itc1short", GasPrice: "-1", GasLimit: "1.5"}, 3},
+		{Profile{Nodes: map[int]string{0: "http://localhost:9500"}, Rewrites: map[string]string{"http://a:9500": "b"}}, 1},
+	}
+	for _, test := range tests {
+		if problems := test.profile.Validate(); len(problems) != test.problems {
+			t.Errorf("Validate(%+v) returned %v, expected %d problems", test.profile, problems, test.problems)
+		}
+	}
+}
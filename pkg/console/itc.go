@@ -6,11 +6,11 @@ import (
 
 	"github.com/dop251/goja"
 	"github.com/intelchain-itc/intelchain/accounts"
-	"github.com/intelchain-itc/intelchain/accounts/keystore"
 	"github.com/intelchain-itc/intelchain/crypto/hash"
+	"github.com/intelchain-itc/itc-sdk/pkg/store"
 )
 
-func signMessageWithPassword(keyStore *keystore.KeyStore, account accounts.Account, password string, data []byte) (sign []byte, err error) {
+func signMessageWithPassword(keyStore store.Keystore, account accounts.Account, password string, data []byte) (sign []byte, err error) {
 	signData := append([]byte("\x19Ethereum Signed Message:\n" + strconv.Itoa(len(data))))
 	msgHash := hash.Keccak256(append(signData, data...))
 
@@ -0,0 +1,192 @@
+package delegation
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"strings"
+
+	"github.com/intelchain-itc/itc-sdk/pkg/common"
+	"github.com/intelchain-itc/itc-sdk/pkg/validator"
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	ErrEmptyBatch        = errors.New("batch has no entries")
+	ErrOverMaxDelegation = errors.New("delegation would exceed the validator's max-total-delegation")
+)
+
+// Entry is one staking transaction of a batch, the amount is in ITC and not
+// used when collecting rewards
+type Entry struct {
+	Delegator      string `yaml:"delegator" json:"delegator"`
+	Validator      string `yaml:"validator" json:"validator"`
+	Amount         string `yaml:"amount" json:"amount"`
+	PassphraseFile string `yaml:"passphrase-file" json:"passphrase-file"`
+}
+
+// Share is the weight of one validator in a split
+type Share struct {
+	Validator string
+	Weight    *big.Rat
+}
+
+// LoadBatch reads a YAML or JSON list of entries
+func LoadBatch(path string) ([]Entry, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	entries := []Entry{}
+	if err := yaml.UnmarshalStrict(data, &entries); err != nil {
+		return nil, fmt.Errorf("could not read batch %s: %w", path, err)
+	}
+	if len(entries) == 0 {
+		return nil, ErrEmptyBatch
+	}
+	return entries, nil
+}
+
+// Atto is the amount of the entry in atto
+func (e Entry) Atto() (*big.Int, error) {
+	return ParseITC(e.Amount)
+}
+
+// ParseITC reads a non-negative amount of ITC, which must be a whole number
+// of atto
+func ParseITC(amount string) (*big.Int, error) {
+	itc, ok := new(big.Rat).SetString(strings.TrimSpace(amount))
+	if !ok || itc.Sign() < 0 {
+		return nil, fmt.Errorf("invalid amount %q", amount)
+	}
+	atto := itc.Mul(itc, new(big.Rat).SetInt(common.AttoPerITC))
+	if !atto.IsInt() {
+		return nil, fmt.Errorf("amount %q has more than 18 decimals", amount)
+	}
+	return new(big.Int).Set(atto.Num()), nil
+}
+
+// ParseSplit reads shares written as validator=weight, weights are relative
+// to their sum so 40/30/30 and 4/3/3 split alike
+func ParseSplit(parts []string) ([]Share, error) {
+	shares := []Share{}
+	seen := map[string]bool{}
+	for _, part := range parts {
+		tokens := strings.Split(part, "=")
+		if len(tokens) != 2 {
+			return nil, fmt.Errorf("split %q must look like <validator>=<weight>", part)
+		}
+		weight, ok := new(big.Rat).SetString(strings.TrimSpace(tokens[1]))
+		if !ok || weight.Sign() <= 0 {
+			return nil, fmt.Errorf("split %q needs a positive weight", part)
+		}
+		validatorAddr := strings.TrimSpace(tokens[0])
+		if seen[validatorAddr] {
+			return nil, fmt.Errorf("validator %s is in the split twice", validatorAddr)
+		}
+		seen[validatorAddr] = true
+		shares = append(shares, Share{validatorAddr, weight})
+	}
+	if len(shares) == 0 {
+		return nil, ErrEmptyBatch
+	}
+	return shares, nil
+}
+
+// Split divides total ITC from delegator across the shares by weight, see
+// splitAtto for the rounding
+func Split(delegator, total string, shares []Share) ([]Entry, error) {
+	totalAtto, err := ParseITC(total)
+	if err != nil {
+		return nil, err
+	}
+	entries := []Entry{}
+	for i, amount := range splitAtto(totalAtto, shares) {
+		entries = append(entries, Entry{
+			Delegator: delegator,
+			Validator: shares[i].Validator,
+			Amount:    common.FormatITC(amount),
+		})
+	}
+	return entries, nil
+}
+
+// splitAtto divides total across the shares by weight. Every share is
+// rounded down to the atto and the last one takes the remainder, so the
+// parts always add up to total.
+func splitAtto(total *big.Int, shares []Share) []*big.Int {
+	weights := new(big.Rat)
+	for _, share := range shares {
+		weights.Add(weights, share.Weight)
+	}
+	parts := []*big.Int{}
+	left := new(big.Int).Set(total)
+	for i, share := range shares {
+		amount := new(big.Int).Set(left)
+		if i != len(shares)-1 {
+			part := new(big.Rat).Mul(new(big.Rat).SetInt(total), share.Weight)
+			part.Quo(part, weights)
+			amount.Quo(part.Num(), part.Denom())
+		}
+		left.Sub(left, amount)
+		parts = append(parts, amount)
+	}
+	return parts
+}
+
+// CheckCapacity makes sure the delegations of a batch, added up per
+// validator, fit within the max-total-delegation of every validator
+func CheckCapacity(entries []Entry, infos map[string]*validator.Information) error {
+	added := map[string]*big.Int{}
+	for _, entry := range entries {
+		amount, err := entry.Atto()
+		if err != nil {
+			return err
+		}
+		if added[entry.Validator] == nil {
+			added[entry.Validator] = new(big.Int)
+		}
+		added[entry.Validator].Add(added[entry.Validator], amount)
+	}
+	for validatorAddr, amount := range added {
+		info, ok := infos[validatorAddr]
+		if !ok {
+			return fmt.Errorf("no information on validator %s", validatorAddr)
+		}
+		max := info.Validator.MaxTotalDelegation
+		if max == nil {
+			continue
+		}
+		total := new(big.Int).Set(amount)
+		if info.TotalDelegation != nil {
+			total.Add(total, info.TotalDelegation)
+		}
+		if total.Cmp(max) > 0 {
+			return fmt.Errorf(
+				"%w: %s would have %s ITC delegated, at most %s ITC allowed",
+				ErrOverMaxDelegation, validatorAddr, common.FormatITC(total), common.FormatITC(max),
+			)
+		}
+	}
+	return nil
+}
+
+// AssignNonces numbers the transactions of every delegator consecutively,
+// starting at the nonce next returns for the delegator
+func AssignNonces(entries []Entry, next func(delegator string) (uint64, error)) ([]uint64, error) {
+	nonces := make([]uint64, len(entries))
+	upcoming := map[string]uint64{}
+	for i, entry := range entries {
+		nonce, ok := upcoming[entry.Delegator]
+		if !ok {
+			var err error
+			if nonce, err = next(entry.Delegator); err != nil {
+				return nil, err
+			}
+		}
+		nonces[i] = nonce
+		upcoming[entry.Delegator] = nonce + 1
+	}
+	return nonces, nil
+}
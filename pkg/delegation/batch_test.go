@@ -0,0 +1,156 @@
+package delegation
+
+import (
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/intelchain-itc/itc-sdk/pkg/validator"
+)
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		total    string
+		split    []string
+		expected []string
+	}{
+		{"10000", []string{"a=40", "b=30", "c=30"}, []string{"4000", "3000", "3000"}},
+		{"10000", []string{"a=4", "b=3", "c=3"}, []string{"4000", "3000", "3000"}},
+		{"1", []string{"a=1", "b=1", "c=1"}, []string{"0.333333333333333333", "0.333333333333333333", "0.333333333333333334"}},
+		{"0.000000000000000001", []string{"a=1", "b=1"}, []string{"0", "0.000000000000000001"}},
+	}
+	for _, test := range tests {
+		shares, err := ParseSplit(test.split)
+		if err != nil {
+			t.Errorf("ParseSplit(%v) failed %v", test.split, err)
+			continue
+		}
+		entries, err := Split("itc1delegator", test.total, shares)
+		if err != nil {
+			t.Errorf("Split(%s, %v) failed %v", test.total, test.split, err)
+			continue
+		}
+		sum := new(big.Int)
+		for i, entry := range entries {
+			if entry.Amount != test.expected[i] || entry.Delegator != "itc1delegator" {
+				t.Errorf("Split(%s, %v) entry #%d is %+v, expected amount %s", test.total, test.split, i+1, entry, test.expected[i])
+			}
+			atto, _ := entry.Atto()
+			sum.Add(sum, atto)
+		}
+		total, _ := ParseITC(test.total)
+		if sum.Cmp(total) != 0 {
+			t.Errorf("Split(%s, %v) adds up to %s atto, expected %s", test.total, test.split, sum, total)
+		}
+	}
+}
+
+func TestParseSplitRejects(t *testing.T) {
+	for _, split := range [][]string{{}, {"a"}, {"a=0"}, {"a=-1"}, {"a=x"}, {"a=1", "a=2"}} {
+		if _, err := ParseSplit(split); err == nil {
+			t.Errorf("ParseSplit(%v) succeeded, expected an error", split)
+		}
+	}
+}
+
+func TestParseITC(t *testing.T) {
+	tests := []struct {
+		amount   string
+		expected string
+	}{
+		{"1", "1000000000000000000"},
+		{"0.5", "500000000000000000"},
+		{"1e-18", "1"},
+		{"0.0000000000000000001", ""},
+		{"-1", ""},
+	}
+	for _, test := range tests {
+		atto, err := ParseITC(test.amount)
+		if test.expected == "" {
+			if err == nil {
+				t.Errorf("ParseITC(%s) returned %s, expected an error", test.amount, atto)
+			}
+			continue
+		}
+		if err != nil || atto.String() != test.expected {
+			t.Errorf("ParseITC(%s) returned %v %v, expected %s", test.amount, atto, err, test.expected)
+		}
+	}
+}
+
+func TestCheckCapacity(t *testing.T) {
+	info := &validator.Information{}
+	info.Validator.MaxTotalDelegation, _ = ParseITC("1000")
+	info.TotalDelegation, _ = ParseITC("700")
+	infos := map[string]*validator.Information{"a": info}
+
+	fits := []Entry{{Validator: "a", Amount: "100"}, {Validator: "a", Amount: "200"}}
+	if err := CheckCapacity(fits, infos); err != nil {
+		t.Errorf("CheckCapacity(%v) failed %v", fits, err)
+	}
+	over := append(fits, Entry{Validator: "a", Amount: "0.1"})
+	if err := CheckCapacity(over, infos); !errors.Is(err, ErrOverMaxDelegation) {
+		t.Errorf("CheckCapacity(%v) returned %v, expected %v", over, err, ErrOverMaxDelegation)
+	}
+	unknown := []Entry{{Validator: "b", Amount: "1"}}
+	if err := CheckCapacity(unknown, infos); err == nil {
+		t.Errorf("CheckCapacity(%v) of an unknown validator succeeded, expected an error", unknown)
+	}
+}
+
+func TestAssignNonces(t *testing.T) {
+	entries := []Entry{{Delegator: "a"}, {Delegator: "b"}, {Delegator: "a"}, {Delegator: "a"}, {Delegator: "b"}}
+	start := map[string]uint64{"a": 5, "b": 0}
+	nonces, err := AssignNonces(entries, func(delegator string) (uint64, error) {
+		return start[delegator], nil
+	})
+	if err != nil {
+		t.Fatalf("AssignNonces failed %v", err)
+	}
+	expected := []uint64{5, 0, 6, 7, 1}
+	for i := range expected {
+		if nonces[i] != expected[i] {
+			t.Errorf("AssignNonces returned %v, expected %v", nonces, expected)
+			break
+		}
+	}
+}
+
+func TestLoadBatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "batch")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "batch.yaml")
+	batch := strings.Join([]string{
+		"- delegator: itc1a",
+		"  validator: itc1v",
+		"  amount: \"100\"",
+		"- delegator: itc1b",
+		"  validator: itc1v",
+		"  amount: \"5.5\"",
+		"  passphrase-file: b.pass",
+	}, "\n")
+	if err := ioutil.WriteFile(path, []byte(batch), 0600); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := LoadBatch(path)
+	if err != nil {
+		t.Fatalf("LoadBatch failed %v", err)
+	}
+	expected := []Entry{{"itc1a", "itc1v", "100", ""}, {"itc1b", "itc1v", "5.5", "b.pass"}}
+	if len(entries) != len(expected) || entries[0] != expected[0] || entries[1] != expected[1] {
+		t.Errorf("LoadBatch returned %+v, expected %+v", entries, expected)
+	}
+	if err := ioutil.WriteFile(path, []byte("[]"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadBatch(path); !errors.Is(err, ErrEmptyBatch) {
+		t.Errorf("LoadBatch of an empty list returned %v, expected %v", err, ErrEmptyBatch)
+	}
+}
@@ -0,0 +1,169 @@
+package delegation
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/intelchain-itc/itc-sdk/pkg/common"
+)
+
+const (
+	// CompoundIdle waits for rewards to pass the threshold
+	CompoundIdle = "idle"
+	// CompoundCollecting has sent the collect-rewards transaction CollectTx
+	// but not seen it confirmed yet
+	CompoundCollecting = "collecting"
+	// CompoundCollected has collected rewards that are not delegated yet
+	CompoundCollected = "collected"
+	// CompoundDelegating is delegating the collected rewards as Pending
+	CompoundDelegating = "delegating"
+)
+
+// CompoundState is what an auto-compounding delegator has done so far, kept
+// between runs so collected rewards are delegated even after a restart
+type CompoundState struct {
+	Delegator  string               `json:"delegator"`
+	Stage      string               `json:"stage"`
+	Collected  string               `json:"collected,omitempty"`
+	CollectTx  string               `json:"collect-tx,omitempty"`
+	Pending    []CompoundDelegation `json:"pending,omitempty"`
+	Compounded string               `json:"compounded"`
+	Rounds     int                  `json:"rounds"`
+	LastCheck  time.Time            `json:"last-check"`
+}
+
+// CompoundDelegation is a delegation of collected rewards that is not
+// confirmed yet, TxHash is set once it was sent
+type CompoundDelegation struct {
+	Entry
+	TxHash string `json:"transaction-hash,omitempty"`
+}
+
+// LoadCompoundState reads the state at path, a missing file is a fresh state
+func LoadCompoundState(path, delegator string) (*CompoundState, error) {
+	state := &CompoundState{Delegator: delegator, Stage: CompoundIdle, Compounded: "0"}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// Save writes the state to path, replacing the previous one in a single
+// rename
+func (s *CompoundState) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Reset goes back to waiting for rewards without counting a round, as when
+// the collect-rewards transaction failed
+func (s *CompoundState) Reset() {
+	s.Stage, s.Collected, s.CollectTx, s.Pending = CompoundIdle, "", "", nil
+}
+
+// Plan records the delegations spreading the collected rewards
+func (s *CompoundState) Plan(entries []Entry) {
+	s.Pending = []CompoundDelegation{}
+	for _, entry := range entries {
+		s.Pending = append(s.Pending, CompoundDelegation{Entry: entry})
+	}
+	s.Stage = CompoundDelegating
+}
+
+// Delegated records that the first pending delegation was confirmed, the
+// round is done once none are left
+func (s *CompoundState) Delegated() error {
+	if len(s.Pending) == 0 {
+		return nil
+	}
+	amount, err := s.Pending[0].Atto()
+	if err != nil {
+		return err
+	}
+	total, err := ParseITC(s.Compounded)
+	if err != nil {
+		return err
+	}
+	s.Compounded = common.FormatITC(total.Add(total, amount))
+	s.Pending = s.Pending[1:]
+	if len(s.Pending) == 0 {
+		s.Reset()
+		s.Rounds++
+	}
+	return nil
+}
+
+// Rewards is the total of the uncollected rewards of the delegations
+func Rewards(delegations []Delegation) *big.Int {
+	rewards := new(big.Int)
+	for _, d := range delegations {
+		if d.Reward != nil {
+			rewards.Add(rewards, d.Reward)
+		}
+	}
+	return rewards
+}
+
+// CompoundEntries spreads amount atto from delegator over its delegations in
+// proportion to their size, or delegates it all to target when one is given.
+// Parts below minDelegation would be rejected, so they go to the largest
+// part instead.
+func CompoundEntries(
+	delegator string, amount *big.Int, delegations []Delegation, target string, minDelegation *big.Int,
+) []Entry {
+	if target != "" {
+		return []Entry{{Delegator: delegator, Validator: target, Amount: common.FormatITC(amount)}}
+	}
+	shares := []Share{}
+	for _, d := range delegations {
+		if d.Amount != nil && d.Amount.Sign() > 0 {
+			shares = append(shares, Share{d.ValidatorAddress, new(big.Rat).SetInt(d.Amount)})
+		}
+	}
+	if len(shares) == 0 {
+		return []Entry{}
+	}
+	parts := splitAtto(amount, shares)
+	largest := 0
+	for i := range parts {
+		if parts[i].Cmp(parts[largest]) > 0 {
+			largest = i
+		}
+	}
+	for i := range parts {
+		if i != largest && minDelegation != nil && parts[i].Cmp(minDelegation) < 0 {
+			parts[largest].Add(parts[largest], parts[i])
+			parts[i].SetInt64(0)
+		}
+	}
+	entries := []Entry{}
+	for i, part := range parts {
+		if part.Sign() > 0 {
+			entries = append(entries, Entry{
+				Delegator: delegator, Validator: shares[i].Validator, Amount: common.FormatITC(part),
+			})
+		}
+	}
+	return entries
+}
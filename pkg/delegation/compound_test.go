@@ -0,0 +1,90 @@
+package delegation
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCompoundEntries(t *testing.T) {
+	minDelegation, _ := ParseITC("100")
+	delegations := []Delegation{
+		testDelegation("a", "6000"), testDelegation("b", "3000"), testDelegation("c", "1000"), testDelegation("d", "0"),
+	}
+	tests := []struct {
+		amount   string
+		target   string
+		expected []Entry
+	}{
+		{"1000", "", []Entry{
+			{"itc1delegator", "a", "600", ""}, {"itc1delegator", "b", "300", ""}, {"itc1delegator", "c", "100", ""},
+		}},
+		{"500", "", []Entry{{"itc1delegator", "a", "350", ""}, {"itc1delegator", "b", "150", ""}}},
+		{"150", "", []Entry{{"itc1delegator", "a", "150", ""}}},
+		{"150", "e", []Entry{{"itc1delegator", "e", "150", ""}}},
+	}
+	for _, test := range tests {
+		amount, _ := ParseITC(test.amount)
+		entries := CompoundEntries("itc1delegator", amount, delegations, test.target, minDelegation)
+		if len(entries) != len(test.expected) {
+			t.Errorf("CompoundEntries(%s, %q) returned %+v, expected %+v", test.amount, test.target, entries, test.expected)
+			continue
+		}
+		for i := range entries {
+			if entries[i] != test.expected[i] {
+				t.Errorf("CompoundEntries(%s, %q) returned %+v, expected %+v", test.amount, test.target, entries, test.expected)
+				break
+			}
+		}
+	}
+}
+
+func TestCompoundStateResumes(t *testing.T) {
+	dir, err := ioutil.TempDir("", "compound")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "state", "compound.json")
+
+	state, err := LoadCompoundState(path, "itc1delegator")
+	if err != nil || state.Stage != CompoundIdle || state.Compounded != "0" {
+		t.Fatalf("LoadCompoundState of a missing file returned %+v %v, expected a fresh state", state, err)
+	}
+	state.Stage, state.Collected, state.CollectTx = CompoundCollecting, "120.5", "0xcollect"
+	if err := state.Save(path); err != nil {
+		t.Fatalf("Save failed %v", err)
+	}
+	resumed, err := LoadCompoundState(path, "itc1delegator")
+	if err != nil || resumed.Stage != CompoundCollecting || resumed.CollectTx != "0xcollect" {
+		t.Fatalf("LoadCompoundState returned %+v %v, expected the collecting stage", resumed, err)
+	}
+	resumed.Plan([]Entry{{"itc1delegator", "a", "100", ""}, {"itc1delegator", "b", "20.5", ""}})
+	resumed.Pending[0].TxHash = "0xa"
+	if err := resumed.Delegated(); err != nil {
+		t.Fatalf("Delegated failed %v", err)
+	}
+	if err := resumed.Save(path); err != nil {
+		t.Fatalf("Save failed %v", err)
+	}
+	resumed, err = LoadCompoundState(path, "itc1delegator")
+	if err != nil || resumed.Stage != CompoundDelegating || len(resumed.Pending) != 1 || resumed.Pending[0].Validator != "b" {
+		t.Fatalf("LoadCompoundState returned %+v %v, expected the delegation to b pending", resumed, err)
+	}
+	if resumed.Compounded != "100" || resumed.Rounds != 0 {
+		t.Errorf("Delegated left %+v, expected 100 compounded in the unfinished round", resumed)
+	}
+	if err := resumed.Delegated(); err != nil {
+		t.Fatalf("Delegated failed %v", err)
+	}
+	if resumed.Stage != CompoundIdle || resumed.Collected != "" || resumed.Compounded != "120.5" || resumed.Rounds != 1 {
+		t.Errorf("Delegated left %+v, expected 120.5 compounded over 1 round", resumed)
+	}
+
+	resumed.Stage, resumed.CollectTx = CompoundCollecting, "0xfailed"
+	resumed.Reset()
+	if resumed.Stage != CompoundIdle || resumed.CollectTx != "" || resumed.Rounds != 1 {
+		t.Errorf("Reset left %+v, expected an idle state without a new round", resumed)
+	}
+}
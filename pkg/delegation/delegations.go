@@ -0,0 +1,59 @@
+package delegation
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/intelchain-itc/itc-sdk/pkg/common"
+	"github.com/intelchain-itc/itc-sdk/pkg/rpc"
+)
+
+// Delegation is a delegation as the node reports it, amounts are in atto
+type Delegation struct {
+	ValidatorAddress string         `json:"validator_address"`
+	DelegatorAddress string         `json:"delegator_address"`
+	Amount           *big.Int       `json:"amount"`
+	Reward           *big.Int       `json:"reward"`
+	Undelegations    []Undelegation `json:"Undelegations"`
+}
+
+// Undelegation is an amount undelegated in an epoch, locked until the lock
+// period has passed
+type Undelegation struct {
+	Amount *big.Int `json:"Amount"`
+	Epoch  *big.Int `json:"Epoch"`
+}
+
+// Locked is the total of the pending undelegations of the delegation
+func (d Delegation) Locked() *big.Int {
+	locked := new(big.Int)
+	for _, u := range d.Undelegations {
+		if u.Amount != nil {
+			locked.Add(locked, u.Amount)
+		}
+	}
+	return locked
+}
+
+// ByDelegator fetches the delegations of a delegator from node
+func ByDelegator(node, delegator string) ([]Delegation, error) {
+	delegations := []Delegation{}
+	if err := rpc.RequestResult(rpc.Method.GetDelegationsByDelegator, node, []interface{}{delegator}, &delegations); err != nil {
+		return nil, err
+	}
+	return delegations, nil
+}
+
+// Balance fetches the balance in atto of an address on the shard of node
+func Balance(node, address string) (*big.Int, error) {
+	var result json.RawMessage
+	if err := rpc.RequestResult(rpc.Method.GetBalance, node, []interface{}{address, "latest"}, &result); err != nil {
+		return nil, err
+	}
+	balance, ok := common.Quantity(result)
+	if !ok {
+		return nil, fmt.Errorf("could not parse balance %s", result)
+	}
+	return balance, nil
+}
@@ -0,0 +1,50 @@
+package delegation
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestByDelegatorKeepsBigAmounts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		call := map[string]interface{}{}
+		json.NewDecoder(r.Body).Decode(&call)
+		id, _ := json.Marshal(call["id"])
+		w.Write([]byte(`{"jsonrpc":"2.0","id":` + string(id) + `,"result":[{` +
+			`"validator_address":"one1validator","delegator_address":"one1delegator",` +
+			`"amount":5000000000000000000000000,"reward":1234567890123456789012,` +
+			`"Undelegations":[{"Amount":2000000000000000000000,"Epoch":100}]}]}`))
+	}))
+	defer server.Close()
+
+	delegations, err := ByDelegator(server.URL, "one1delegator")
+	if err != nil {
+		t.Fatalf("ByDelegator returned error %v", err)
+	}
+	if len(delegations) != 1 {
+		t.Fatalf("ByDelegator returned %d delegations, expected 1", len(delegations))
+	}
+	d := delegations[0]
+	if d.Amount.String() != "5000000000000000000000000" || d.Reward.String() != "1234567890123456789012" ||
+		d.Locked().String() != "2000000000000000000000" {
+		t.Errorf("ByDelegator returned amount %s, reward %s and locked %s, expected them exact", d.Amount, d.Reward, d.Locked())
+	}
+}
+
+func TestBalanceKeepsBigAmounts(t *testing.T) {
+	for _, result := range []string{`"0x108b2a2c28029094000000"`, `20000000000000000000000000`} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			call := map[string]interface{}{}
+			json.NewDecoder(r.Body).Decode(&call)
+			id, _ := json.Marshal(call["id"])
+			w.Write([]byte(`{"jsonrpc":"2.0","id":` + string(id) + `,"result":` + result + `}`))
+		}))
+		balance, err := Balance(server.URL, "one1delegator")
+		server.Close()
+		if err != nil || balance.String() != "20000000000000000000000000" {
+			t.Errorf("Balance(%s) returned %v %v, expected 20000000000000000000000000", result, balance, err)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package delegation
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/intelchain-itc/itc-sdk/pkg/common"
+)
+
+const (
+	// ActionDelegate and ActionUndelegate name the moves of a rebalance
+	ActionDelegate   = "delegate"
+	ActionUndelegate = "undelegate"
+	// ActionSkip is a delegation left out of a rebalance, nothing is sent
+	ActionSkip = "skip"
+)
+
+// Holding is the stake of a delegator with one validator, before and after
+// a rebalance, in ITC
+type Holding struct {
+	Validator string `json:"validator"`
+	Active    string `json:"active"`
+	Locked    string `json:"locked"`
+	Target    string `json:"target"`
+}
+
+// Move is one transaction of a rebalance
+type Move struct {
+	Action    string `json:"action"`
+	Validator string `json:"validator"`
+	Amount    string `json:"amount"`
+	Note      string `json:"note,omitempty"`
+}
+
+// RebalancePlan lists the transactions taking the delegations of a
+// delegator to a target allocation, undelegations first. Delegations are
+// funded from pending undelegations before the balance, the chain
+// redelegates locked tokens so they need not wait for the lock to end.
+type RebalancePlan struct {
+	Delegator   string    `json:"delegator"`
+	Total       string    `json:"total"`
+	Holdings    []Holding `json:"holdings"`
+	Moves       []Move    `json:"moves"`
+	Unallocated string    `json:"unallocated"`
+}
+
+// RebalanceOptions tune a rebalance, amounts are in atto
+type RebalanceOptions struct {
+	// Extra is balance to stake on top of the current delegations
+	Extra *big.Int
+	// MinDelegation is the smallest amount a delegation can be, or be left at
+	MinDelegation *big.Int
+}
+
+// Entries are the moves of one action as batch entries of the delegator
+func (p *RebalancePlan) Entries(action string) []Entry {
+	entries := []Entry{}
+	for _, move := range p.Moves {
+		if move.Action == action {
+			entries = append(entries, Entry{
+				Delegator: p.Delegator, Validator: move.Validator, Amount: move.Amount,
+			})
+		}
+	}
+	return entries
+}
+
+// PlanRebalance works out the fewest transactions, at most one per
+// validator, that spread the active and locked stake of a delegator plus
+// opts.Extra across the target shares. Delegations to validators missing
+// from the target are undelegated in full. A delegation below the minimum
+// is not made but listed as skipped, and an undelegation that would leave
+// less than the minimum takes everything.
+func PlanRebalance(
+	delegator string, delegations []Delegation, target []Share, opts RebalanceOptions,
+) (*RebalancePlan, error) {
+	minDelegation := opts.MinDelegation
+	if minDelegation == nil {
+		minDelegation = new(big.Int)
+	}
+	active, locked := map[string]*big.Int{}, map[string]*big.Int{}
+	order := []string{}
+	total := new(big.Int)
+	funds := new(big.Int)
+	if opts.Extra != nil {
+		if opts.Extra.Sign() < 0 {
+			return nil, fmt.Errorf("extra stake can not be negative")
+		}
+		total.Add(total, opts.Extra)
+		funds.Add(funds, opts.Extra)
+	}
+	for _, d := range delegations {
+		if _, seen := active[d.ValidatorAddress]; !seen {
+			order = append(order, d.ValidatorAddress)
+			active[d.ValidatorAddress], locked[d.ValidatorAddress] = new(big.Int), new(big.Int)
+		}
+		if d.Amount != nil {
+			active[d.ValidatorAddress].Add(active[d.ValidatorAddress], d.Amount)
+			total.Add(total, d.Amount)
+		}
+		locked[d.ValidatorAddress].Add(locked[d.ValidatorAddress], d.Locked())
+		total.Add(total, d.Locked())
+		funds.Add(funds, d.Locked())
+	}
+
+	targets := map[string]*big.Int{}
+	for i, amount := range splitAtto(total, target) {
+		v := target[i].Validator
+		targets[v] = amount
+		if _, seen := active[v]; !seen {
+			order = append(order, v)
+			active[v], locked[v] = new(big.Int), new(big.Int)
+		}
+	}
+
+	plan := &RebalancePlan{Delegator: delegator, Total: common.FormatITC(total), Moves: []Move{}}
+	delegateMoves := []Move{}
+	for _, v := range order {
+		goal, ok := targets[v]
+		if !ok {
+			goal = new(big.Int)
+		}
+		plan.Holdings = append(plan.Holdings, Holding{
+			v, common.FormatITC(active[v]), common.FormatITC(locked[v]), common.FormatITC(goal),
+		})
+		diff := new(big.Int).Sub(goal, active[v])
+		switch diff.Sign() {
+		case -1:
+			amount := new(big.Int).Neg(diff)
+			note := ""
+			if goal.Sign() > 0 && goal.Cmp(minDelegation) < 0 {
+				amount.Set(active[v])
+				note = "target below the minimum delegation, undelegating all"
+			}
+			funds.Add(funds, amount)
+			plan.Moves = append(plan.Moves, Move{ActionUndelegate, v, common.FormatITC(amount), note})
+		case 1:
+			delegateMoves = append(delegateMoves, Move{ActionDelegate, v, common.FormatITC(diff), ""})
+		}
+	}
+	for _, move := range delegateMoves {
+		amount, _ := ParseITC(move.Amount)
+		if amount.Cmp(minDelegation) < 0 {
+			plan.Moves = append(plan.Moves, Move{
+				ActionSkip, move.Validator, move.Amount, "below the minimum delegation, not delegating",
+			})
+			continue
+		}
+		funds.Sub(funds, amount)
+		plan.Moves = append(plan.Moves, move)
+	}
+	plan.Unallocated = common.FormatITC(funds)
+	return plan, nil
+}
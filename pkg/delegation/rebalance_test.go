@@ -0,0 +1,108 @@
+package delegation
+
+import (
+	"testing"
+)
+
+func testDelegation(validatorAddr, amount string, locked ...string) Delegation {
+	d := Delegation{ValidatorAddress: validatorAddr, DelegatorAddress: "itc1delegator"}
+	d.Amount, _ = ParseITC(amount)
+	for _, l := range locked {
+		u := Undelegation{}
+		u.Amount, _ = ParseITC(l)
+		d.Undelegations = append(d.Undelegations, u)
+	}
+	return d
+}
+
+func TestPlanRebalance(t *testing.T) {
+	minDelegation, _ := ParseITC("100")
+	tests := []struct {
+		name        string
+		delegations []Delegation
+		target      []string
+		extra       string
+		expected    []Move
+		unallocated string
+	}{{
+		"move between validators",
+		[]Delegation{testDelegation("a", "1000"), testDelegation("b", "1000")},
+		[]string{"a=25", "b=25", "c=50"},
+		"0",
+		[]Move{{ActionUndelegate, "a", "500", ""}, {ActionUndelegate, "b", "500", ""}, {ActionDelegate, "c", "1000", ""}},
+		"0",
+	}, {
+		"locked stake and extra balance are allocated",
+		[]Delegation{testDelegation("a", "1000", "300", "200")},
+		[]string{"a=1", "b=1"},
+		"500",
+		[]Move{{ActionDelegate, "b", "1000", ""}},
+		"0",
+	}, {
+		"validators left out of the target are exited",
+		[]Delegation{testDelegation("a", "1000"), testDelegation("b", "400")},
+		[]string{"a=1"},
+		"0",
+		[]Move{{ActionUndelegate, "b", "400", ""}, {ActionDelegate, "a", "400", ""}},
+		"0",
+	}, {
+		"delegations under the minimum are not made",
+		[]Delegation{testDelegation("a", "1000"), testDelegation("b", "950")},
+		[]string{"a=1", "b=1"},
+		"0",
+		[]Move{{ActionUndelegate, "a", "25", ""}, {ActionSkip, "b", "25", "below the minimum delegation, not delegating"}},
+		"25",
+	}, {
+		"undelegations never leave less than the minimum",
+		[]Delegation{testDelegation("a", "1000"), testDelegation("b", "200")},
+		[]string{"a=95", "b=5"},
+		"0",
+		[]Move{
+			{ActionUndelegate, "b", "200", "target below the minimum delegation, undelegating all"},
+			{ActionDelegate, "a", "140", ""},
+		},
+		"60",
+	}, {
+		"already balanced",
+		[]Delegation{testDelegation("a", "500"), testDelegation("b", "500")},
+		[]string{"a=1", "b=1"},
+		"0",
+		[]Move{},
+		"0",
+	}}
+
+	for _, test := range tests {
+		shares, err := ParseSplit(test.target)
+		if err != nil {
+			t.Fatal(err)
+		}
+		extra, _ := ParseITC(test.extra)
+		plan, err := PlanRebalance("itc1delegator", test.delegations, shares, RebalanceOptions{extra, minDelegation})
+		if err != nil {
+			t.Errorf("PlanRebalance(%s) failed %v", test.name, err)
+			continue
+		}
+		if len(plan.Moves) != len(test.expected) {
+			t.Errorf("PlanRebalance(%s) returned %+v, expected %+v", test.name, plan.Moves, test.expected)
+			continue
+		}
+		for i := range plan.Moves {
+			if plan.Moves[i] != test.expected[i] {
+				t.Errorf("PlanRebalance(%s) move #%d is %+v, expected %+v", test.name, i+1, plan.Moves[i], test.expected[i])
+			}
+		}
+		if plan.Unallocated != test.unallocated {
+			t.Errorf("PlanRebalance(%s) leaves %s unallocated, expected %s", test.name, plan.Unallocated, test.unallocated)
+		}
+	}
+}
+
+func TestRebalancePlanEntries(t *testing.T) {
+	plan := &RebalancePlan{Delegator: "itc1delegator", Moves: []Move{
+		{ActionUndelegate, "a", "5", ""}, {ActionDelegate, "b", "3", ""}, {ActionDelegate, "c", "2", ""},
+	}}
+	entries := plan.Entries(ActionDelegate)
+	if len(entries) != 2 || entries[0] != (Entry{"itc1delegator", "b", "3", ""}) || entries[1].Validator != "c" {
+		t.Errorf("Entries(%s) returned %+v", ActionDelegate, entries)
+	}
+}
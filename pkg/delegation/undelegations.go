@@ -0,0 +1,83 @@
+package delegation
+
+import (
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/intelchain-itc/itc-sdk/pkg/clock"
+	"github.com/intelchain-itc/itc-sdk/pkg/common"
+)
+
+// PendingUndelegation is an undelegation waiting out its lock, amounts are
+// in ITC
+type PendingUndelegation struct {
+	Validator    string    `json:"validator"`
+	Amount       string    `json:"amount"`
+	Epoch        uint64    `json:"undelegated-epoch"`
+	ReleaseEpoch uint64    `json:"release-epoch"`
+	EpochsLeft   uint64    `json:"epochs-left"`
+	Release      time.Time `json:"estimated-release"`
+	Spendable    bool      `json:"spendable"`
+}
+
+// ValidatorLockup sums the pending undelegations from one validator
+type ValidatorLockup struct {
+	Validator   string    `json:"validator"`
+	Locked      string    `json:"locked"`
+	Entries     int       `json:"entries"`
+	NextRelease time.Time `json:"next-release"`
+}
+
+// Lockups lists the pending undelegations of delegations, soonest release
+// first, with totals per validator, placing release epochs in time by c. An
+// undelegation of epoch E becomes spendable in epoch E+lockEpochs.
+func Lockups(
+	delegations []Delegation, c *clock.Clock, lockEpochs uint64, now time.Time,
+) ([]PendingUndelegation, []ValidatorLockup) {
+	pending := []PendingUndelegation{}
+	totals := map[string]*big.Int{}
+	byValidator := map[string]*ValidatorLockup{}
+	order := []string{}
+	for _, d := range delegations {
+		for _, u := range d.Undelegations {
+			if u.Amount == nil || u.Epoch == nil {
+				continue
+			}
+			release := u.Epoch.Uint64() + lockEpochs
+			p := PendingUndelegation{
+				Validator:    d.ValidatorAddress,
+				Amount:       common.FormatITC(u.Amount),
+				Epoch:        u.Epoch.Uint64(),
+				ReleaseEpoch: release,
+				Release:      now,
+				Spendable:    release <= c.Latest.Epoch,
+			}
+			if !p.Spendable {
+				p.EpochsLeft = release - c.Latest.Epoch
+				p.Release = c.EpochTime(release)
+			}
+			pending = append(pending, p)
+
+			lockup, ok := byValidator[d.ValidatorAddress]
+			if !ok {
+				lockup = &ValidatorLockup{Validator: d.ValidatorAddress, NextRelease: p.Release}
+				byValidator[d.ValidatorAddress] = lockup
+				totals[d.ValidatorAddress] = new(big.Int)
+				order = append(order, d.ValidatorAddress)
+			}
+			totals[d.ValidatorAddress].Add(totals[d.ValidatorAddress], u.Amount)
+			lockup.Entries++
+			if p.Release.Before(lockup.NextRelease) {
+				lockup.NextRelease = p.Release
+			}
+		}
+	}
+	sort.SliceStable(pending, func(i, j int) bool { return pending[i].ReleaseEpoch < pending[j].ReleaseEpoch })
+	lockups := []ValidatorLockup{}
+	for _, v := range order {
+		byValidator[v].Locked = common.FormatITC(totals[v])
+		lockups = append(lockups, *byValidator[v])
+	}
+	return pending, lockups
+}
@@ -0,0 +1,45 @@
+package delegation
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/intelchain-itc/itc-sdk/pkg/clock"
+)
+
+func TestLockups(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	// epoch 100 ends in an hour, epochs last 18 hours
+	c := &clock.Clock{
+		Latest:         clock.Block{Number: 1000, Epoch: 100, Time: now},
+		EpochStart:     575,
+		BlocksPerEpoch: 450,
+		BlockTime:      144 * time.Second,
+	}
+	delegations := []Delegation{
+		testDelegation("a", "1000", "50", "20"),
+		testDelegation("b", "1000", "30"),
+	}
+	delegations[0].Undelegations[0].Epoch = big.NewInt(99)
+	delegations[0].Undelegations[1].Epoch = big.NewInt(93)
+	delegations[1].Undelegations[0].Epoch = big.NewInt(96)
+
+	pending, lockups := Lockups(delegations, c, 7, now)
+	expected := []PendingUndelegation{
+		{"a", "20", 93, 100, 0, now, true},
+		{"b", "30", 96, 103, 3, now.Add(37 * time.Hour), false},
+		{"a", "50", 99, 106, 6, now.Add(91 * time.Hour), false},
+	}
+	if !reflect.DeepEqual(pending, expected) {
+		t.Errorf("Lockups returned %+v, expected %+v", pending, expected)
+	}
+	expectedLockups := []ValidatorLockup{
+		{"a", "70", 2, now},
+		{"b", "30", 1, now.Add(37 * time.Hour)},
+	}
+	if !reflect.DeepEqual(lockups, expectedLockups) {
+		t.Errorf("Lockups returned totals %+v, expected %+v", lockups, expectedLockups)
+	}
+}
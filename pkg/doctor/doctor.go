@@ -0,0 +1,297 @@
+package doctor
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/intelchain-itc/itc-sdk/pkg/common"
+	"github.com/intelchain-itc/itc-sdk/pkg/rpc"
+)
+
+const (
+	// SeverityError marks a finding that breaks transactions
+	SeverityError = "error"
+	// SeverityWarning marks a finding that may slow or confuse them
+	SeverityWarning = "warning"
+)
+
+// Endpoint is an RPC endpoint to examine, ShardID is the shard the sharding
+// structure routes it for or -1 when it is not known
+type Endpoint struct {
+	Name    string
+	URL     string
+	ShardID int
+}
+
+// Check is what probing one endpoint found
+type Check struct {
+	Name            string    `json:"name"`
+	URL             string    `json:"url"`
+	ShardID         int       `json:"shard-id"`
+	Reachable       bool      `json:"reachable"`
+	Error           string    `json:"error,omitempty"`
+	LatencyMS       int64     `json:"latency-ms"`
+	ReportedShardID *int64    `json:"reported-shard-id,omitempty"`
+	Height          uint64    `json:"height"`
+	HeightLag       uint64    `json:"height-lag"`
+	BlockTime       time.Time `json:"block-time"`
+	Syncing         bool      `json:"syncing"`
+	Peers           *int64    `json:"peers,omitempty"`
+	ChainID         string    `json:"chain-id,omitempty"`
+	Network         string    `json:"network,omitempty"`
+	Version         string    `json:"version,omitempty"`
+	ProtocolVersion string    `json:"protocol-version,omitempty"`
+}
+
+// Finding is a problem the doctor found
+type Finding struct {
+	Severity string `json:"severity"`
+	Endpoint string `json:"endpoint,omitempty"`
+	Message  string `json:"message"`
+}
+
+// Expect is what the CLI is configured for and the limits to flag beyond.
+// MaxHeightLag is in blocks behind the highest endpoint of the same shard.
+type Expect struct {
+	ChainID      *big.Int
+	Network      string
+	MaxLatency   time.Duration
+	MaxBlockAge  time.Duration
+	MaxHeightLag uint64
+}
+
+// Report is the outcome of examining every endpoint
+type Report struct {
+	ChainID  string    `json:"cli-chain-id"`
+	Network  string    `json:"cli-network"`
+	Checks   []Check   `json:"endpoints"`
+	Findings []Finding `json:"findings"`
+}
+
+type metadata struct {
+	Version     string `json:"version"`
+	Network     string `json:"network"`
+	ChainConfig struct {
+		ChainID *big.Int `json:"chain-id"`
+	} `json:"chain-config"`
+}
+
+type header struct {
+	Number   uint64 `json:"blockNumber"`
+	UnixTime int64  `json:"unixtime"`
+}
+
+// Probe examines an endpoint with a single batch of requests
+func Probe(e Endpoint) Check {
+	check := Check{Name: e.Name, URL: e.URL, ShardID: e.ShardID}
+	calls := []rpc.Call{
+		{Method: rpc.Method.GetNodeMetadata, Params: []interface{}{}},
+		{Method: rpc.Method.GetLatestBlockHeader, Params: []interface{}{}},
+		{Method: rpc.Method.Syncing, Params: []interface{}{}},
+		{Method: rpc.Method.PeerCount, Params: []interface{}{}},
+		{Method: rpc.Method.ProtocolVersion, Params: []interface{}{}},
+		{Method: rpc.Method.GetShardID, Params: []interface{}{}},
+	}
+	started := time.Now()
+	results, errs, err := rpc.BatchRequest(e.URL, calls)
+	check.LatencyMS = time.Since(started).Milliseconds()
+	if err != nil {
+		check.Error = err.Error()
+		return check
+	}
+	check.Reachable = true
+
+	meta := metadata{}
+	if errs[0] == nil && json.Unmarshal(results[0], &meta) == nil {
+		check.Network, check.Version = meta.Network, meta.Version
+		if meta.ChainConfig.ChainID != nil {
+			check.ChainID = meta.ChainConfig.ChainID.String()
+		}
+	}
+	head := header{}
+	if errs[1] == nil && json.Unmarshal(results[1], &head) == nil {
+		check.Height = head.Number
+		if head.UnixTime > 0 {
+			check.BlockTime = time.Unix(head.UnixTime, 0).UTC()
+		}
+	}
+	if errs[2] == nil {
+		var syncing interface{}
+		if json.Unmarshal(results[2], &syncing) == nil {
+			// nodes answer false when in sync and describe the sync otherwise
+			check.Syncing = syncing != nil && syncing != false
+		}
+	}
+	if errs[3] == nil {
+		if peers, ok := common.Quantity(results[3]); ok {
+			n := peers.Int64()
+			check.Peers = &n
+		}
+	}
+	if errs[4] == nil {
+		if version, ok := common.Quantity(results[4]); ok {
+			check.ProtocolVersion = version.String()
+		}
+	}
+	if errs[5] == nil {
+		if shard, ok := common.Quantity(results[5]); ok {
+			n := shard.Int64()
+			check.ReportedShardID = &n
+		}
+	}
+	for _, err := range errs {
+		if err != nil && check.Error == "" {
+			check.Error = err.Error()
+		}
+	}
+	return check
+}
+
+// Examine probes every endpoint and diagnoses the result
+func Examine(endpoints []Endpoint, expect Expect) *Report {
+	checks := make([]Check, len(endpoints))
+	for i, e := range endpoints {
+		checks[i] = Probe(e)
+	}
+	return Diagnose(checks, expect, time.Now())
+}
+
+// servedShard is the shard an endpoint reports it serves, or else the one
+// it is routed for, -1 when neither is known
+func (c Check) servedShard() int64 {
+	if c.ReportedShardID != nil {
+		return *c.ReportedShardID
+	}
+	return int64(c.ShardID)
+}
+
+// Diagnose turns the checks of the endpoints into findings. Heights are only
+// comparable within a shard, so the height lag of an endpoint is counted
+// from the highest endpoint serving the same shard, while shards are
+// compared with each other by the age of their latest block.
+func Diagnose(checks []Check, expect Expect, now time.Time) *Report {
+	checks = append([]Check{}, checks...)
+	report := &Report{Network: expect.Network, Checks: checks, Findings: []Finding{}}
+	if expect.ChainID != nil {
+		report.ChainID = expect.ChainID.String()
+	}
+	find := func(severity, endpoint, format string, args ...interface{}) {
+		report.Findings = append(report.Findings, Finding{severity, endpoint, fmt.Sprintf(format, args...)})
+	}
+
+	var newest time.Time
+	protocols := map[string][]string{}
+	tips := map[int64]Check{}
+	for _, c := range checks {
+		if c.Reachable && c.BlockTime.After(newest) {
+			newest = c.BlockTime
+		}
+		if tip, ok := tips[c.servedShard()]; c.Reachable && c.Height > 0 && (!ok || c.Height > tip.Height) {
+			tips[c.servedShard()] = c
+		}
+	}
+	for i := range checks {
+		if tip, ok := tips[checks[i].servedShard()]; ok && checks[i].Reachable && checks[i].Height > 0 {
+			checks[i].HeightLag = tip.Height - checks[i].Height
+		}
+	}
+	for _, c := range checks {
+		if !c.Reachable {
+			find(SeverityError, c.Name, "unreachable: %s", c.Error)
+			continue
+		}
+		if c.Error != "" {
+			find(SeverityWarning, c.Name, "some requests failed: %s", c.Error)
+		}
+		if expect.MaxLatency > 0 && time.Duration(c.LatencyMS)*time.Millisecond > expect.MaxLatency {
+			find(SeverityWarning, c.Name, "answered in %dms, slower than %s", c.LatencyMS, expect.MaxLatency)
+		}
+		if c.ChainID != "" && report.ChainID != "" && c.ChainID != report.ChainID {
+			find(SeverityError, c.Name,
+				"node is on chain ID %s (%s) but the CLI signs for chain ID %s (%s), set --chain-id or --node to match",
+				c.ChainID, c.Network, report.ChainID, expect.Network)
+		} else if c.Network != "" && expect.Network != "" && !strings.EqualFold(c.Network, expect.Network) {
+			find(SeverityWarning, c.Name, "node serves the %s network but the CLI targets %s", c.Network, expect.Network)
+		}
+		if c.ShardID >= 0 && c.ReportedShardID != nil && *c.ReportedShardID != int64(c.ShardID) {
+			find(SeverityError, c.Name, "routed as shard %d but serves shard %d", c.ShardID, *c.ReportedShardID)
+		}
+		if c.Syncing {
+			find(SeverityWarning, c.Name, "node is still syncing, balances and nonces may be stale")
+		}
+		if c.Peers != nil && *c.Peers == 0 {
+			find(SeverityError, c.Name, "node has no peers, transactions sent to it will not spread")
+		}
+		if !c.BlockTime.IsZero() {
+			if age := now.Sub(c.BlockTime); expect.MaxBlockAge > 0 && age > expect.MaxBlockAge {
+				find(SeverityWarning, c.Name, "latest block %d is %s old, %s behind the freshest shard",
+					c.Height, age.Round(time.Second), newest.Sub(c.BlockTime).Round(time.Second))
+			}
+		}
+		if shard := c.servedShard(); expect.MaxHeightLag > 0 && shard >= 0 && c.HeightLag > expect.MaxHeightLag {
+			find(SeverityWarning, c.Name, "block %d is %d blocks behind %s on shard %d",
+				c.Height, c.HeightLag, tips[shard].Name, shard)
+		}
+		if c.ProtocolVersion != "" {
+			protocols[c.ProtocolVersion] = append(protocols[c.ProtocolVersion], c.Name)
+		}
+	}
+	if len(protocols) > 1 {
+		versions := []string{}
+		for version, names := range protocols {
+			versions = append(versions, fmt.Sprintf("%s on %s", version, strings.Join(names, ", ")))
+		}
+		sort.Strings(versions)
+		find(SeverityWarning, "", "endpoints speak different protocol versions: %s", strings.Join(versions, "; "))
+	}
+	return report
+}
+
+// Healthy reports whether the report has no errors
+func (r *Report) Healthy() bool {
+	for _, f := range r.Findings {
+		if f.Severity == SeverityError {
+			return false
+		}
+	}
+	return true
+}
+
+// WriteTo writes the report for people to read
+func (r *Report) WriteTo(w io.Writer) (int64, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "CLI chain ID %s (%s)\n\n", r.ChainID, r.Network)
+	for _, c := range r.Checks {
+		if !c.Reachable {
+			fmt.Fprintf(&b, "%-10s %s\n           unreachable\n", c.Name, c.URL)
+			continue
+		}
+		peers := "?"
+		if c.Peers != nil {
+			peers = fmt.Sprint(*c.Peers)
+		}
+		fmt.Fprintf(&b, "%-10s %s\n", c.Name, c.URL)
+		fmt.Fprintf(&b, "           %dms, block %d (%d behind its shard) at %s, syncing %v, %s peers\n",
+			c.LatencyMS, c.Height, c.HeightLag, c.BlockTime.Format(time.RFC3339), c.Syncing, peers)
+		fmt.Fprintf(&b, "           chain ID %s (%s), version %s, protocol %s\n",
+			c.ChainID, c.Network, c.Version, c.ProtocolVersion)
+	}
+	b.WriteString("\n")
+	if len(r.Findings) == 0 {
+		b.WriteString("No problems found\n")
+	}
+	for _, f := range r.Findings {
+		if f.Endpoint != "" {
+			fmt.Fprintf(&b, "%-7s %s: %s\n", strings.ToUpper(f.Severity), f.Endpoint, f.Message)
+		} else {
+			fmt.Fprintf(&b, "%-7s %s\n", strings.ToUpper(f.Severity), f.Message)
+		}
+	}
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
@@ -0,0 +1,116 @@
+package doctor
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProbe(t *testing.T) {
+	results := map[string]interface{}{
+		"itc_getNodeMetadata": map[string]interface{}{
+			"version": "v1.10.3", "network": "testnet", "chain-config": map[string]interface{}{"chain-id": 2},
+		},
+		"itc_latestHeader":    map[string]interface{}{"blockNumber": 120, "unixtime": 1700000000},
+		"itc_syncing":         map[string]interface{}{"current-block": 100, "target-block": 120},
+		"net_peerCount":       "0x1a",
+		"itc_protocolVersion": "0x10",
+		"itc_getShardID":      1,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls := []map[string]interface{}{}
+		json.NewDecoder(r.Body).Decode(&calls)
+		replies := []map[string]interface{}{}
+		for _, call := range calls {
+			reply := map[string]interface{}{"jsonrpc": "2.0", "id": call["id"]}
+			if result, ok := results[call["method"].(string)]; ok {
+				reply["result"] = result
+			} else {
+				reply["error"] = map[string]interface{}{"code": -32601, "message": "method not found " + call["method"].(string)}
+			}
+			replies = append(replies, reply)
+		}
+		json.NewEncoder(w).Encode(replies)
+	}))
+	defer server.Close()
+
+	check := Probe(Endpoint{"shard-1", server.URL, 1})
+	if !check.Reachable || check.Height != 120 || !check.Syncing || check.ChainID != "2" || check.Network != "testnet" {
+		t.Errorf("Probe returned %+v, expected a reachable syncing testnet node at block 120", check)
+	}
+	if check.Peers == nil || *check.Peers != 26 || check.ReportedShardID == nil || *check.ReportedShardID != 1 {
+		t.Errorf("Probe returned %+v, expected 26 peers on shard 1", check)
+	}
+
+	unreachable := Probe(Endpoint{"shard-0", "http://127.0.0.1:1", 0})
+	if unreachable.Reachable || unreachable.Error == "" {
+		t.Errorf("Probe of a closed port returned %+v, expected it unreachable", unreachable)
+	}
+}
+
+func TestDiagnose(t *testing.T) {
+	now := time.Unix(1700000100, 0)
+	peers, none, shard0, shard1 := int64(20), int64(0), int64(0), int64(1)
+	checks := []Check{
+		{Name: "shard-0", ShardID: 0, Reachable: true, ChainID: "1", Network: "mainnet", Peers: &peers,
+			ReportedShardID: &shard0, Height: 1000, BlockTime: now.Add(-5 * time.Second), ProtocolVersion: "16"},
+		{Name: "shard-1", ShardID: 1, Reachable: true, ChainID: "1", Network: "mainnet", Peers: &none,
+			ReportedShardID: &shard0, Height: 990, BlockTime: now.Add(-5 * time.Minute), Syncing: true, LatencyMS: 3000, ProtocolVersion: "16"},
+		{Name: "shard-2", ShardID: 2, Reachable: false, Error: "connection refused"},
+		{Name: "node", ShardID: -1, Reachable: true, ChainID: "2", Network: "testnet",
+			ReportedShardID: &shard1, Height: 500, BlockTime: now, ProtocolVersion: "17"},
+	}
+	report := Diagnose(checks, Expect{
+		ChainID: big.NewInt(1), Network: "mainnet", MaxLatency: 2 * time.Second, MaxBlockAge: time.Minute, MaxHeightLag: 5,
+	}, now)
+
+	type finding struct{ severity, endpoint, contains string }
+	expected := []finding{
+		{SeverityWarning, "shard-1", "slower than 2s"},
+		{SeverityError, "shard-1", "routed as shard 1 but serves shard 0"},
+		{SeverityWarning, "shard-1", "still syncing"},
+		{SeverityError, "shard-1", "no peers"},
+		{SeverityWarning, "shard-1", "5m0s old, 5m0s behind"},
+		{SeverityWarning, "shard-1", "block 990 is 10 blocks behind shard-0 on shard 0"},
+		{SeverityError, "shard-2", "unreachable: connection refused"},
+		{SeverityError, "node", "chain ID 2 (testnet) but the CLI signs for chain ID 1 (mainnet)"},
+		{SeverityWarning, "", "16 on shard-0, shard-1; 17 on node"},
+	}
+	result := []finding{}
+	for _, f := range report.Findings {
+		result = append(result, finding{f.Severity, f.Endpoint, f.Message})
+	}
+	if len(result) != len(expected) {
+		t.Fatalf("Diagnose returned %v, expected %v", result, expected)
+	}
+	for i := range expected {
+		if result[i].severity != expected[i].severity || result[i].endpoint != expected[i].endpoint ||
+			!strings.Contains(result[i].contains, expected[i].contains) {
+			t.Errorf("Diagnose finding %d is %v, expected %v", i, result[i], expected[i])
+		}
+	}
+	if report.Healthy() {
+		t.Errorf("Healthy() returned true for a report with errors")
+	}
+	for i, lag := range []uint64{0, 10, 0, 0} {
+		if report.Checks[i].HeightLag != lag {
+			t.Errorf("Diagnose returned height lag %d for %s, expected %d", report.Checks[i].HeightLag, checks[i].Name, lag)
+		}
+	}
+
+	healthy := Diagnose(checks[:1], Expect{ChainID: big.NewInt(1), Network: "mainnet"}, now)
+	if !healthy.Healthy() || !reflect.DeepEqual(healthy.Findings, []Finding{}) {
+		t.Errorf("Diagnose of a healthy endpoint returned %+v", healthy.Findings)
+	}
+	var b bytes.Buffer
+	healthy.WriteTo(&b)
+	if !strings.Contains(b.String(), "No problems found") {
+		t.Errorf("WriteTo wrote %s, expected no problems", b.String())
+	}
+}
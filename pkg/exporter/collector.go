@@ -0,0 +1,244 @@
+package exporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/intelchain-itc/itc-sdk/pkg/common"
+	"github.com/intelchain-itc/itc-sdk/pkg/delegation"
+	"github.com/intelchain-itc/itc-sdk/pkg/rpc"
+	"github.com/intelchain-itc/itc-sdk/pkg/validator"
+)
+
+// Config is what an exporter scrapes. Shards maps each shard of the network
+// to its RPC endpoint, shard 0 being the beacon chain.
+type Config struct {
+	Node       string
+	Shards     map[int]string
+	Addresses  []string
+	Validators []string
+}
+
+// Collect scrapes the configured addresses, validators and nodes with one
+// batched request per endpoint and returns the metrics
+func Collect(config Config) *Metrics {
+	m := NewMetrics()
+	heights := map[int]*big.Int{}
+	shardIDs := []int{}
+	for shardID := range config.Shards {
+		shardIDs = append(shardIDs, shardID)
+	}
+	sort.Ints(shardIDs)
+	for _, shardID := range shardIDs {
+		heights[shardID] = collectShard(m, shardID, config.Shards[shardID], config.Addresses)
+	}
+	if beacon, ok := config.Shards[0]; ok && (len(config.Addresses) > 0 || len(config.Validators) > 0) {
+		collectStaking(m, beacon, config.Addresses, config.Validators)
+	}
+	if config.Node != "" {
+		collectNode(m, config.Node, heights)
+	}
+	return m
+}
+
+func scraped(m *Metrics, source string, started time.Time, err error) {
+	m.Gauge("itc_scrape_success", "Whether the last scrape of a source succeeded", boolValue(err == nil), "source", source)
+	m.Gauge("itc_scrape_duration_seconds", "How long the last scrape of a source took", time.Since(started).Seconds(), "source", source)
+}
+
+// collectShard records the height of a shard and the balances and nonces of
+// the addresses on it, returning the height
+func collectShard(m *Metrics, shardID int, endpoint string, addresses []string) *big.Int {
+	started := time.Now()
+	shard := strconv.Itoa(shardID)
+	calls := []rpc.Call{{Method: rpc.Method.BlockNumber, Params: []interface{}{}}}
+	for _, addr := range addresses {
+		calls = append(calls,
+			rpc.Call{Method: rpc.Method.GetBalance, Params: []interface{}{addr, "latest"}},
+			rpc.Call{Method: rpc.Method.GetTransactionCount, Params: []interface{}{addr, "latest"}},
+		)
+	}
+	results, errs, err := rpc.BatchRequest(endpoint, calls)
+	if err != nil {
+		scraped(m, "shard-"+shard, started, err)
+		return nil
+	}
+	var height *big.Int
+	if errs[0] == nil {
+		if h, ok := common.Quantity(results[0]); ok {
+			height = h
+			m.Gauge("itc_shard_block_height", "Latest block of a shard", float64(h.Uint64()), "shard", shard)
+		}
+	}
+	for i, addr := range addresses {
+		if errs[1+2*i] == nil {
+			if balance, ok := common.Quantity(results[1+2*i]); ok {
+				m.Gauge("itc_balance", "Balance of an address on a shard in ITC", toITC(balance), "address", addr, "shard", shard)
+			}
+		}
+		if errs[2+2*i] == nil {
+			if nonce, ok := common.Quantity(results[2+2*i]); ok {
+				m.Gauge("itc_nonce", "Nonce of an address on a shard", float64(nonce.Uint64()), "address", addr, "shard", shard)
+			}
+		}
+	}
+	scraped(m, "shard-"+shard, started, firstError(errs))
+	return height
+}
+
+// collectStaking records the delegations of the addresses and the state of
+// the validators from the beacon chain
+func collectStaking(m *Metrics, beacon string, addresses, validators []string) {
+	started := time.Now()
+	calls := []rpc.Call{{Method: rpc.Method.GetElectedValidatorAddresses, Params: []interface{}{}}}
+	for _, addr := range addresses {
+		calls = append(calls, rpc.Call{Method: rpc.Method.GetDelegationsByDelegator, Params: []interface{}{addr}})
+	}
+	for _, addr := range validators {
+		calls = append(calls, rpc.Call{Method: rpc.Method.GetValidatorInformation, Params: []interface{}{addr}})
+	}
+	results, errs, err := rpc.BatchRequest(beacon, calls)
+	if err != nil {
+		scraped(m, "staking", started, err)
+		return
+	}
+
+	elected := map[string]bool{}
+	electedAddresses := []string{}
+	if errs[0] == nil && json.Unmarshal(results[0], &electedAddresses) == nil {
+		for _, addr := range electedAddresses {
+			elected[addr] = true
+		}
+	}
+	for i, addr := range addresses {
+		delegations := []delegation.Delegation{}
+		if errs[1+i] != nil {
+			continue
+		}
+		if errs[1+i] = json.Unmarshal(results[1+i], &delegations); errs[1+i] != nil {
+			continue
+		}
+		for _, d := range delegations {
+			m.Gauge("itc_delegation_amount", "Amount delegated in ITC", toITC(d.Amount),
+				"delegator", addr, "validator", d.ValidatorAddress)
+			m.Gauge("itc_delegation_reward", "Uncollected reward of a delegation in ITC", toITC(d.Reward),
+				"delegator", addr, "validator", d.ValidatorAddress)
+			m.Gauge("itc_delegation_locked", "Pending undelegations of a delegation in ITC", toITC(d.Locked()),
+				"delegator", addr, "validator", d.ValidatorAddress)
+		}
+	}
+	for i, addr := range validators {
+		j := 1 + len(addresses) + i
+		info := &validator.Information{}
+		if errs[j] != nil {
+			continue
+		}
+		if errs[j] = json.Unmarshal(results[j], info); errs[j] != nil {
+			continue
+		}
+		r := validator.NewRanking(info)
+		m.Gauge("itc_validator_elected", "Whether the validator is elected this epoch", boolValue(elected[addr]), "validator", addr)
+		m.Gauge("itc_validator_active", "Whether the validator is active", boolValue(r.Active), "validator", addr)
+		m.Gauge("itc_validator_signing_rate", "Share of blocks signed this epoch", r.EpochUptime, "validator", addr)
+		m.Gauge("itc_validator_lifetime_signing_rate", "Share of blocks signed since creation", r.Uptime, "validator", addr)
+		m.Gauge("itc_validator_total_delegation", "Total delegation of the validator in ITC", toITC(r.TotalDelegation), "validator", addr)
+		m.Gauge("itc_validator_max_total_delegation", "Max total delegation of the validator in ITC", toITC(r.MaxTotalDelegation), "validator", addr)
+		m.Gauge("itc_validator_commission_rate", "Commission rate of the validator", r.Rate, "validator", addr)
+		m.Gauge("itc_validator_elected_keys", "Bls keys of the validator in the committee", float64(len(info.ElectedKeys())), "validator", addr)
+	}
+	scraped(m, "staking", started, firstError(errs))
+}
+
+// collectNode records the height of the node and how far it lags the shard
+// it serves
+func collectNode(m *Metrics, node string, heights map[int]*big.Int) {
+	started := time.Now()
+	results, errs, err := rpc.BatchRequest(node, []rpc.Call{
+		{Method: rpc.Method.GetShardID, Params: []interface{}{}},
+		{Method: rpc.Method.BlockNumber, Params: []interface{}{}},
+	})
+	if err == nil {
+		err = firstError(errs)
+	}
+	if err != nil {
+		scraped(m, "node", started, err)
+		return
+	}
+	shardID, okShard := common.Quantity(results[0])
+	height, okHeight := common.Quantity(results[1])
+	if !okShard || !okHeight {
+		scraped(m, "node", started, fmt.Errorf("could not read the shard and height of %s", node))
+		return
+	}
+	shard := shardID.String()
+	m.Gauge("itc_node_block_height", "Latest block of the node", float64(height.Uint64()), "node", node, "shard", shard)
+	if shardHeight, ok := heights[int(shardID.Int64())]; ok && shardHeight != nil {
+		lag := new(big.Int).Sub(shardHeight, height)
+		if lag.Sign() < 0 {
+			lag.SetInt64(0)
+		}
+		m.Gauge("itc_node_sync_lag_blocks", "Blocks the node is behind its shard", float64(lag.Uint64()), "node", node, "shard", shard)
+	}
+	scraped(m, "node", started, nil)
+}
+
+func firstError(errs []error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Exporter scrapes on its own interval and serves the latest metrics, so
+// Prometheus scraping more often adds no load on the node
+type Exporter struct {
+	config   Config
+	interval time.Duration
+	lock     sync.RWMutex
+	latest   []byte
+}
+
+// NewExporter returns an exporter of config scraping every interval
+func NewExporter(config Config, interval time.Duration) *Exporter {
+	return &Exporter{config: config, interval: interval}
+}
+
+// Scrape collects the metrics once and keeps them for serving
+func (e *Exporter) Scrape() {
+	var b bytes.Buffer
+	Collect(e.config).WriteTo(&b)
+	e.lock.Lock()
+	e.latest = b.Bytes()
+	e.lock.Unlock()
+}
+
+// Run scrapes every interval until stop is closed
+func (e *Exporter) Run(stop <-chan struct{}) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+	for {
+		e.Scrape()
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ServeHTTP serves the latest metrics
+func (e *Exporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	w.Write(e.latest)
+}
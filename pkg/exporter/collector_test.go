@@ -0,0 +1,95 @@
+package exporter
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// fakeNode answers batches the way a node of shard answers them
+func fakeNode(t *testing.T, shard int, height string) *httptest.Server {
+	results := map[string]interface{}{
+		"itc_blockNumber":                  height,
+		"itc_getShardID":                   shard,
+		"itc_getBalance":                   "0x3635c9adc5dea00000",
+		"itc_getTransactionCount":          "0x7",
+		"itc_getElectedValidatorAddresses": []string{"itc1validator"},
+		"itc_getDelegationsByDelegator": []map[string]interface{}{{
+			"validator_address": "itc1validator", "delegator_address": "itc1delegator",
+			"amount": json.Number("2000000000000000000000"), "reward": json.Number("5000000000000000000"), "Undelegations": []map[string]interface{}{{"Amount": json.Number("1000000000000000000"), "Epoch": 3}},
+		}},
+		"itc_getValidatorInformation": map[string]interface{}{
+			"validator": map[string]interface{}{
+				"address": "itc1validator", "rate": "0.1", "bls-public-keys": []string{"aa"}, "max-total-delegation": json.Number("4000000000000000000000"),
+			},
+			"current-epoch-performance": map[string]interface{}{
+				"current-epoch-signing-percent": map[string]interface{}{"current-epoch-signed": 45, "current-epoch-to-sign": 50},
+			},
+			"total-delegation": json.Number("2000000000000000000000"), "active-status": "active",
+		},
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls := []map[string]interface{}{}
+		if err := json.NewDecoder(r.Body).Decode(&calls); err != nil {
+			t.Errorf("node received a request that is not a batch: %v", err)
+		}
+		replies := []map[string]interface{}{}
+		for _, call := range calls {
+			reply := map[string]interface{}{"jsonrpc": "2.0", "id": call["id"]}
+			if result, ok := results[call["method"].(string)]; ok {
+				reply["result"] = result
+			} else {
+				reply["error"] = map[string]interface{}{"code": -32601, "message": "method not found"}
+			}
+			replies = append(replies, reply)
+		}
+		json.NewEncoder(w).Encode(replies)
+	}))
+}
+
+func TestCollect(t *testing.T) {
+	beacon, shard1, node := fakeNode(t, 0, "0x64"), fakeNode(t, 1, "0xc8"), fakeNode(t, 1, "0xc0")
+	defer beacon.Close()
+	defer shard1.Close()
+	defer node.Close()
+
+	var b bytes.Buffer
+	Collect(Config{
+		Node:       node.URL,
+		Shards:     map[int]string{0: beacon.URL, 1: shard1.URL},
+		Addresses:  []string{"itc1delegator"},
+		Validators: []string{"itc1validator"},
+	}).WriteTo(&b)
+	out := b.String()
+	for _, expected := range []string{
+		`itc_balance{address="itc1delegator",shard="1"} 1000`,
+		`itc_nonce{address="itc1delegator",shard="0"} 7`,
+		`itc_shard_block_height{shard="1"} 200`,
+		`itc_delegation_amount{delegator="itc1delegator",validator="itc1validator"} 2000`,
+		`itc_delegation_reward{delegator="itc1delegator",validator="itc1validator"} 5`,
+		`itc_delegation_locked{delegator="itc1delegator",validator="itc1validator"} 1`,
+		`itc_validator_elected{validator="itc1validator"} 1`,
+		`itc_validator_signing_rate{validator="itc1validator"} 0.9`,
+		`itc_validator_max_total_delegation{validator="itc1validator"} 4000`,
+		`itc_node_sync_lag_blocks{node="` + node.URL + `",shard="1"} 8`,
+		`itc_scrape_success{source="staking"} 1`,
+		"# TYPE itc_balance gauge\n",
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("Collect did not report %s, got:\n%s", expected, out)
+		}
+	}
+}
+
+func TestCollectUnreachable(t *testing.T) {
+	var b bytes.Buffer
+	Collect(Config{Shards: map[int]string{0: "http://127.0.0.1:1"}, Addresses: []string{"itc1delegator"}}).WriteTo(&b)
+	for _, expected := range []string{`itc_scrape_success{source="shard-0"} 0`, `itc_scrape_success{source="staking"} 0`} {
+		if !strings.Contains(b.String(), expected) {
+			t.Errorf("Collect of an unreachable node did not report %s, got:\n%s", expected, b.String())
+		}
+	}
+}
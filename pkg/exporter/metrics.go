@@ -0,0 +1,103 @@
+package exporter
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/intelchain-itc/itc-sdk/pkg/common"
+)
+
+// Label is a name and value pair of a sample
+type Label struct {
+	Name  string
+	Value string
+}
+
+// Sample is one value of a metric
+type Sample struct {
+	Labels []Label
+	Value  float64
+}
+
+// Family is a metric with its help text and samples, kind is gauge or
+// counter
+type Family struct {
+	Name    string
+	Help    string
+	Kind    string
+	Samples []Sample
+}
+
+// Metrics are the families of one scrape by name
+type Metrics struct {
+	families map[string]*Family
+}
+
+// NewMetrics returns empty metrics
+func NewMetrics() *Metrics {
+	return &Metrics{families: map[string]*Family{}}
+}
+
+// Gauge records a gauge sample, labels alternate names and values
+func (m *Metrics) Gauge(name, help string, value float64, labels ...string) {
+	family, ok := m.families[name]
+	if !ok {
+		family = &Family{Name: name, Help: help, Kind: "gauge"}
+		m.families[name] = family
+	}
+	sample := Sample{Value: value}
+	for i := 0; i+1 < len(labels); i += 2 {
+		sample.Labels = append(sample.Labels, Label{labels[i], labels[i+1]})
+	}
+	family.Samples = append(family.Samples, sample)
+}
+
+// Family returns the family called name, or nil
+func (m *Metrics) Family(name string) *Family {
+	return m.families[name]
+}
+
+// WriteTo writes the metrics in the Prometheus text format, families sorted
+// by name
+func (m *Metrics) WriteTo(w io.Writer) (int64, error) {
+	names := []string{}
+	for name := range m.families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	for _, name := range names {
+		family := m.families[name]
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", name, family.Help, name, family.Kind)
+		for _, sample := range family.Samples {
+			b.WriteString(name)
+			if len(sample.Labels) > 0 {
+				parts := []string{}
+				for _, label := range sample.Labels {
+					parts = append(parts, fmt.Sprintf("%s=%s", label.Name, strconv.Quote(label.Value)))
+				}
+				b.WriteString("{" + strings.Join(parts, ",") + "}")
+			}
+			b.WriteString(" " + strconv.FormatFloat(sample.Value, 'g', -1, 64) + "\n")
+		}
+	}
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+// toITC is an atto amount in ITC as a sample value
+func toITC(atto *big.Int) float64 {
+	f, _ := strconv.ParseFloat(common.FormatITC(atto), 64)
+	return f
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -4,10 +4,10 @@ import (
 	"fmt"
 
 	"github.com/intelchain-itc/intelchain/accounts"
-	"github.com/intelchain-itc/intelchain/accounts/keystore"
+	"github.com/intelchain-itc/itc-sdk/pkg/store"
 )
 
-func DoVote(keyStore *keystore.KeyStore, account accounts.Account, vote Vote) error {
+func DoVote(keyStore store.Keystore, account accounts.Account, vote Vote) error {
 	typedData, err := vote.ToEIP712()
 	if err != nil {
 		return err
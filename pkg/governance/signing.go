@@ -5,8 +5,8 @@ import (
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/intelchain-itc/intelchain/accounts"
-	"github.com/intelchain-itc/intelchain/accounts/keystore"
 	"github.com/intelchain-itc/intelchain/crypto/hash"
+	"github.com/intelchain-itc/itc-sdk/pkg/store"
 	"github.com/pkg/errors"
 )
 
@@ -33,7 +33,7 @@ func encodeForSigning(typedData *TypedData) ([]byte, error) {
 
 // signTypedData encodes and signs EIP-712 data
 // it is copied over here from Geth to use our own keystore implementation
-func signTypedData(keyStore *keystore.KeyStore, account accounts.Account, typedData *TypedData) (string, error) {
+func signTypedData(keyStore store.Keystore, account accounts.Account, typedData *TypedData) (string, error) {
 	rawData, err := encodeForSigning(typedData)
 	if err != nil {
 		return "", errors.Wrapf(
@@ -54,7 +54,7 @@ func signTypedData(keyStore *keystore.KeyStore, account accounts.Account, typedD
 	return hexutil.Encode(sign), nil
 }
 
-// func signMessage(keyStore *keystore.KeyStore, account accounts.Account, data []byte) (string, error) {
+// func signMessage(keyStore store.Keystore, account accounts.Account, data []byte) (string, error) {
 // 	fullMessage := fmt.Sprintf("\x19Ethereum Signed Message:\n%d%s", len(data), data)
 // 	msgHash := hash.Keccak256Hash([]byte(fullMessage))
 // 	sign, err := keyStore.SignHash(account, msgHash.Bytes())
@@ -0,0 +1,74 @@
+package history
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/intelchain-itc/itc-sdk/pkg/common"
+)
+
+// The export formats of a history
+const (
+	CSV   = "csv"
+	JSONL = "jsonl"
+)
+
+// Header names the fields of an exported transaction
+var Header = []string{
+	"time", "hash", "kind", "shard", "to-shard", "block", "from", "to",
+	"direction", "counterparty", "amount", "fee", "status", "nonce",
+}
+
+// Fields are the fields of the transaction in the order of Header, amounts
+// in ITC
+func (t Transaction) Fields() []string {
+	return []string{
+		t.Time.Format(time.RFC3339),
+		t.Hash,
+		t.Kind,
+		strconv.FormatUint(uint64(t.Shard), 10),
+		strconv.FormatUint(uint64(t.ToShard), 10),
+		strconv.FormatUint(t.Block, 10),
+		t.From,
+		t.To,
+		t.Direction,
+		t.Counterparty,
+		common.FormatITC(t.Amount),
+		common.FormatITC(t.Fee),
+		t.Status,
+		strconv.FormatUint(t.Nonce, 10),
+	}
+}
+
+// Write writes the transactions to w in format, CSV with a header row or
+// JSONL with an object of the fields of Header per line
+func Write(w io.Writer, format string, txs []Transaction) error {
+	switch format {
+	case CSV:
+		c := csv.NewWriter(w)
+		c.Write(Header)
+		for _, t := range txs {
+			c.Write(t.Fields())
+		}
+		c.Flush()
+		return c.Error()
+	case JSONL:
+		encoder := json.NewEncoder(w)
+		for _, t := range txs {
+			record := map[string]string{}
+			for i, field := range t.Fields() {
+				record[Header[i]] = field
+			}
+			if err := encoder.Encode(record); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown export format %s, expected %s or %s", format, CSV, JSONL)
+	}
+}
@@ -0,0 +1,302 @@
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/intelchain-itc/itc-sdk/pkg/common"
+	"github.com/intelchain-itc/itc-sdk/pkg/rpc"
+)
+
+const (
+	// KindTransfer is the kind of plain transactions, staking transactions
+	// have the kind of their directive such as Delegate
+	KindTransfer = "transfer"
+	// KindCollectRewards is the kind of reward collections, their amount is
+	// the rewards their receipt reports collected
+	KindCollectRewards = "CollectRewards"
+
+	// DirectionIn is a transaction received by the account
+	DirectionIn = "in"
+	// DirectionOut is a transaction sent by the account
+	DirectionOut = "out"
+	// DirectionSelf is a transaction from the account to itself
+	DirectionSelf = "self"
+
+	// StatusSuccess is a transaction its receipt reports executed
+	StatusSuccess = "success"
+	// StatusFailed is a transaction its receipt reports failed
+	StatusFailed = "failed"
+	// StatusUnknown is a transaction without a receipt
+	StatusUnknown = "unknown"
+
+	receiptBatchSize = 100
+)
+
+// Transaction is an entry of the history of an account, amounts are in atto.
+// Raw is the transaction object as the node sent it.
+type Transaction struct {
+	Raw          json.RawMessage
+	Hash         string
+	Kind         string
+	Shard        uint32
+	ToShard      uint32
+	Block        uint64
+	Time         time.Time
+	From         string
+	To           string
+	Direction    string
+	Counterparty string
+	Amount       *big.Int
+	GasPrice     *big.Int
+	Fee          *big.Int
+	Status       string
+	Nonce        uint64
+}
+
+// Query is the history to fetch: the transactions of Address, paged by
+// PageSize, with its staking transactions when Staking is set. Fetching
+// stops at transactions older than Since when it is set.
+type Query struct {
+	Address  string
+	PageSize int
+	Staking  bool
+	Since    time.Time
+}
+
+// Filter keeps the transactions in [Since, Until), with Counterparty on the
+// other side and in Direction, each when set
+type Filter struct {
+	Since        time.Time
+	Until        time.Time
+	Counterparty string
+	Direction    string
+}
+
+type historyParams struct {
+	Address   string `json:"address"`
+	PageIndex int    `json:"pageIndex"`
+	PageSize  int    `json:"pageSize"`
+	FullTx    bool   `json:"fullTx"`
+	TxType    string `json:"txType"`
+	Order     string `json:"order"`
+}
+
+type rawTransaction struct {
+	Hash        string          `json:"hash"`
+	BlockNumber json.RawMessage `json:"blockNumber"`
+	Timestamp   json.RawMessage `json:"timestamp"`
+	From        string          `json:"from"`
+	To          string          `json:"to"`
+	Value       json.RawMessage `json:"value"`
+	GasPrice    json.RawMessage `json:"gasPrice"`
+	Nonce       json.RawMessage `json:"nonce"`
+	ShardID     uint32          `json:"shardID"`
+	ToShardID   uint32          `json:"toShardID"`
+	Type        string          `json:"type"`
+	Msg         struct {
+		ValidatorAddress string          `json:"validatorAddress"`
+		Amount           json.RawMessage `json:"amount"`
+	} `json:"msg"`
+}
+
+type page struct {
+	Transactions        []json.RawMessage `json:"transactions"`
+	StakingTransactions []json.RawMessage `json:"staking_transactions"`
+}
+
+type receipt struct {
+	GasUsed json.RawMessage `json:"gasUsed"`
+	Status  json.RawMessage `json:"status"`
+	Logs    []struct {
+		Data json.RawMessage `json:"data"`
+	} `json:"logs"`
+}
+
+// quantity is a number the node sent, zero when it can not be read
+func quantity(raw json.RawMessage) *big.Int {
+	if n, ok := common.Quantity(raw); ok {
+		return n
+	}
+	return new(big.Int)
+}
+
+func sameAddress(a, b string) bool {
+	return a != "" && strings.EqualFold(a, b)
+}
+
+func (r rawTransaction) transaction(address string, shard uint32, staking bool) Transaction {
+	t := Transaction{
+		Hash:     r.Hash,
+		Kind:     KindTransfer,
+		Shard:    r.ShardID,
+		ToShard:  r.ToShardID,
+		Block:    quantity(r.BlockNumber).Uint64(),
+		Time:     time.Unix(quantity(r.Timestamp).Int64(), 0).UTC(),
+		From:     r.From,
+		To:       r.To,
+		Amount:   quantity(r.Value),
+		GasPrice: quantity(r.GasPrice),
+		Fee:      new(big.Int),
+		Status:   StatusUnknown,
+		Nonce:    quantity(r.Nonce).Uint64(),
+	}
+	if staking {
+		t.Kind, t.To, t.Amount = r.Type, r.Msg.ValidatorAddress, quantity(r.Msg.Amount)
+		t.Shard, t.ToShard = shard, shard
+	}
+	switch {
+	case sameAddress(t.From, address) && sameAddress(t.To, address):
+		t.Direction, t.Counterparty = DirectionSelf, address
+	case sameAddress(t.From, address):
+		t.Direction, t.Counterparty = DirectionOut, t.To
+	default:
+		t.Direction, t.Counterparty = DirectionIn, t.From
+	}
+	return t
+}
+
+// fetchPages pages through one kind of history of node, newest first
+func fetchPages(node string, q Query, shard uint32, staking bool) ([]Transaction, error) {
+	method := rpc.Method.GetTransactionsHistory
+	if staking {
+		method = rpc.Method.GetStakingTransactionsHistory
+	}
+	txs := []Transaction{}
+	for index := 0; ; index++ {
+		params := historyParams{q.Address, index, q.PageSize, true, "ALL", "DESC"}
+		result := page{}
+		if err := rpc.RequestResult(method, node, []interface{}{params}, &result); err != nil {
+			return nil, err
+		}
+		raws := result.Transactions
+		if staking {
+			raws = result.StakingTransactions
+		}
+		for _, raw := range raws {
+			r := rawTransaction{}
+			if err := json.Unmarshal(raw, &r); err != nil {
+				return nil, err
+			}
+			t := r.transaction(q.Address, shard, staking)
+			t.Raw = raw
+			txs = append(txs, t)
+		}
+		if len(raws) < q.PageSize {
+			return txs, nil
+		}
+		if oldest := txs[len(txs)-1]; !q.Since.IsZero() && oldest.Time.Before(q.Since) {
+			return txs, nil
+		}
+	}
+}
+
+// Fetch pages through the history of the account on the shard of node
+func Fetch(node string, q Query, shard uint32) ([]Transaction, error) {
+	if q.PageSize <= 0 {
+		return nil, fmt.Errorf("invalid page size %d", q.PageSize)
+	}
+	txs, err := fetchPages(node, q, shard, false)
+	if err != nil {
+		return nil, err
+	}
+	if q.Staking {
+		staking, err := fetchPages(node, q, shard, true)
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, staking...)
+	}
+	return txs, nil
+}
+
+// Enrich fills in the fee and status of the transactions, and the rewards of
+// reward collections, from their receipts, asking the node of the shard each was sent on in batches
+func Enrich(nodes map[uint32]string, txs []Transaction) error {
+	byShard := map[uint32][]int{}
+	for i, t := range txs {
+		byShard[t.Shard] = append(byShard[t.Shard], i)
+	}
+	for shard, indexes := range byShard {
+		node, ok := nodes[shard]
+		if !ok {
+			return fmt.Errorf("no node for shard %d", shard)
+		}
+		for start := 0; start < len(indexes); start += receiptBatchSize {
+			end := start + receiptBatchSize
+			if end > len(indexes) {
+				end = len(indexes)
+			}
+			if err := enrich(node, txs, indexes[start:end]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func enrich(node string, txs []Transaction, indexes []int) error {
+	calls := []rpc.Call{}
+	for _, i := range indexes {
+		calls = append(calls, rpc.Call{Method: rpc.Method.GetTransactionReceipt, Params: []interface{}{txs[i].Hash}})
+	}
+	results, errs, err := rpc.BatchRequest(node, calls)
+	if err != nil {
+		return err
+	}
+	for j, i := range indexes {
+		r := receipt{}
+		if errs[j] != nil || json.Unmarshal(results[j], &r) != nil || len(r.Status) == 0 {
+			continue
+		}
+		t := &txs[i]
+		t.Fee = new(big.Int).Mul(quantity(r.GasUsed), t.GasPrice)
+		t.Status = StatusFailed
+		if quantity(r.Status).Sign() > 0 {
+			t.Status = StatusSuccess
+		}
+		if t.Kind == KindCollectRewards && len(r.Logs) > 0 {
+			t.Amount = quantity(r.Logs[0].Data)
+		}
+	}
+	return nil
+}
+
+// Keeps reports whether the filter keeps t
+func (f Filter) Keeps(t Transaction) bool {
+	if !f.Since.IsZero() && t.Time.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && !t.Time.Before(f.Until) {
+		return false
+	}
+	if f.Counterparty != "" && !sameAddress(t.Counterparty, f.Counterparty) {
+		return false
+	}
+	return f.Direction == "" || t.Direction == f.Direction
+}
+
+// Apply keeps the transactions the filter keeps, the first of each hash,
+// oldest first
+func (f Filter) Apply(txs []Transaction) []Transaction {
+	kept := []Transaction{}
+	seen := map[string]bool{}
+	for _, t := range txs {
+		if seen[t.Hash] || !f.Keeps(t) {
+			continue
+		}
+		seen[t.Hash] = true
+		kept = append(kept, t)
+	}
+	sort.SliceStable(kept, func(i, j int) bool {
+		if !kept[i].Time.Equal(kept[j].Time) {
+			return kept[i].Time.Before(kept[j].Time)
+		}
+		return kept[i].Nonce < kept[j].Nonce
+	})
+	return kept
+}
@@ -0,0 +1,208 @@
+package history
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	account = "one1account"
+	friend  = "one1friend"
+	pool    = "one1validator"
+)
+
+// newNode serves a history of 5 transfers newest first, a delegation, a
+// collection of 3 ITC of rewards and the receipts of every transaction but 0x3
+func newNode(t *testing.T) *httptest.Server {
+	transfers := []map[string]interface{}{}
+	for i := 5; i > 0; i-- {
+		from, to := friend, account
+		if i%2 == 0 {
+			from, to = account, friend
+		}
+		transfers = append(transfers, map[string]interface{}{
+			"hash": fmt.Sprintf("0x%d", i), "blockNumber": fmt.Sprintf("0x%x", 100+i),
+			"timestamp": 1000 * i, "from": from, "to": to, "value": "1000000000000000000",
+			"gasPrice": "0x64", "nonce": i, "shardID": 0, "toShardID": 0,
+		})
+	}
+	staking := []map[string]interface{}{{
+		"hash": "0x6", "blockNumber": 200, "timestamp": 6000, "from": account, "type": "Delegate",
+		"gasPrice": 100, "nonce": 6, "msg": map[string]interface{}{"validatorAddress": pool, "amount": 2e18},
+	}, {
+		"hash": "0x7", "blockNumber": 300, "timestamp": 7000, "from": account, "type": "CollectRewards",
+		"gasPrice": 100, "nonce": 7, "msg": map[string]interface{}{"delegatorAddress": account},
+	}}
+	answer := func(call map[string]interface{}) map[string]interface{} {
+		reply := map[string]interface{}{"jsonrpc": "2.0", "id": call["id"]}
+		params := call["params"].([]interface{})
+		switch call["method"] {
+		case "itc_getTransactionsHistory", "itc_getStakingTransactionsHistory":
+			p := params[0].(map[string]interface{})
+			if p["txType"] != "ALL" || p["order"] != "DESC" || p["fullTx"] != true {
+				t.Errorf("history requested with %v", p)
+			}
+			index, size := int(p["pageIndex"].(float64)), int(p["pageSize"].(float64))
+			if call["method"] == "itc_getStakingTransactionsHistory" {
+				reply["result"] = map[string]interface{}{"staking_transactions": staking[min(index*size, 2):min(index*size+size, 2)]}
+			} else {
+				reply["result"] = map[string]interface{}{"transactions": transfers[min(index*size, 5):min(index*size+size, 5)]}
+			}
+		case "itc_getTransactionReceipt":
+			switch params[0] {
+			case "0x3":
+			case "0x7":
+				reply["result"] = map[string]interface{}{"gasUsed": "0x5208", "status": "0x1", "logs": []map[string]interface{}{
+					{"data": "0x00000000000000000000000000000000000000000000000029a2241af62c0000"},
+				}}
+			default:
+				reply["result"] = map[string]interface{}{"gasUsed": "0x5208", "status": "0x1"}
+			}
+		}
+		return reply
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if bytes.HasPrefix(body, []byte("[")) {
+			calls, replies := []map[string]interface{}{}, []map[string]interface{}{}
+			json.Unmarshal(body, &calls)
+			for _, call := range calls {
+				replies = append(replies, answer(call))
+			}
+			json.NewEncoder(w).Encode(replies)
+			return
+		}
+		call := map[string]interface{}{}
+		json.Unmarshal(body, &call)
+		json.NewEncoder(w).Encode(answer(call))
+	}))
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func hashes(txs []Transaction) string {
+	list := []string{}
+	for _, tx := range txs {
+		list = append(list, tx.Hash)
+	}
+	return strings.Join(list, " ")
+}
+
+func TestFetch(t *testing.T) {
+	server := newNode(t)
+	defer server.Close()
+
+	tests := []struct {
+		query    Query
+		expected string
+	}{
+		{Query{Address: account, PageSize: 2}, "0x5 0x4 0x3 0x2 0x1"},
+		{Query{Address: account, PageSize: 5}, "0x5 0x4 0x3 0x2 0x1"},
+		{Query{Address: account, PageSize: 2, Staking: true}, "0x5 0x4 0x3 0x2 0x1 0x6 0x7"},
+		{Query{Address: account, PageSize: 2, Since: time.Unix(3500, 0)}, "0x5 0x4 0x3 0x2"},
+	}
+	for _, test := range tests {
+		txs, err := Fetch(server.URL, test.query, 0)
+		if err != nil {
+			t.Fatalf("Fetch(%+v) returned error %v", test.query, err)
+		}
+		if result := hashes(txs); result != test.expected {
+			t.Errorf("Fetch(%+v) returned %s, expected %s", test.query, result, test.expected)
+		}
+	}
+	if _, err := Fetch(server.URL, Query{Address: account}, 0); err == nil {
+		t.Errorf("Fetch without a page size returned no error")
+	}
+}
+
+func TestEnrichAndExport(t *testing.T) {
+	server := newNode(t)
+	defer server.Close()
+
+	txs, err := Fetch(server.URL, Query{Address: account, PageSize: 10, Staking: true}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Enrich(map[uint32]string{0: server.URL}, txs); err != nil {
+		t.Fatalf("Enrich returned error %v", err)
+	}
+	rewards, _ := new(big.Int).SetString("3000000000000000000", 10)
+	if collected := txs[len(txs)-1]; collected.Amount.Cmp(rewards) != 0 {
+		t.Errorf("Enrich returned rewards %s, expected %s", collected.Amount, rewards)
+	}
+	txs = Filter{Since: time.Unix(2000, 0), Until: time.Unix(6000, 0)}.Apply(append(txs, txs[0]))
+	if result := hashes(txs); result != "0x2 0x3 0x4 0x5" {
+		t.Errorf("Apply returned %s, expected 0x2 0x3 0x4 0x5", result)
+	}
+	if err := Enrich(map[uint32]string{}, txs); err == nil {
+		t.Errorf("Enrich without the node of shard 0 returned no error")
+	}
+	fee := big.NewInt(21000 * 100)
+	if txs[0].Status != StatusSuccess || txs[0].Fee.Cmp(fee) != 0 || txs[1].Status != StatusUnknown {
+		t.Errorf("Enrich returned %+v, expected success with fee %s but for 0x3", txs, fee)
+	}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal(txs[0].Raw, &raw); err != nil || raw["hash"] != "0x2" || raw["gasPrice"] != "0x64" {
+		t.Errorf("Fetch kept raw %s, expected the node's object of 0x2", txs[0].Raw)
+	}
+
+	out := bytes.Buffer{}
+	if err := Write(&out, CSV, txs[:1]); err != nil {
+		t.Fatal(err)
+	}
+	expected := strings.Join(Header, ",") + "\n" +
+		"1970-01-01T00:33:20Z,0x2,transfer,0,0,102,one1account,one1friend,out,one1friend,1,0.0000000000021,success,2\n"
+	if out.String() != expected {
+		t.Errorf("Write(csv) returned %q, expected %q", out.String(), expected)
+	}
+	out.Reset()
+	if err := Write(&out, JSONL, txs); err != nil {
+		t.Fatal(err)
+	}
+	first := strings.SplitN(out.String(), "\n", 2)[0]
+	record := map[string]string{}
+	if err := json.Unmarshal([]byte(first), &record); err != nil {
+		t.Fatal(err)
+	}
+	if record["hash"] != "0x2" || record["direction"] != "out" || record["amount"] != "1" ||
+		record["fee"] != "0.0000000000021" || record["status"] != "success" {
+		t.Errorf("Write(jsonl) returned %s, expected 0x2 sent with its fee", first)
+	}
+	if lines := strings.Count(out.String(), "\n"); lines != len(txs) || !strings.Contains(out.String(), `"direction":"in"`) {
+		t.Errorf("Write(jsonl) returned %s, expected %d lines", out.String(), len(txs))
+	}
+}
+
+func TestFilter(t *testing.T) {
+	delegation := Transaction{Hash: "0x6", Kind: "Delegate", Time: time.Unix(6000, 0), Direction: DirectionOut, Counterparty: pool}
+	received := Transaction{Hash: "0x1", Kind: KindTransfer, Time: time.Unix(1000, 0), Direction: DirectionIn, Counterparty: friend}
+	tests := []struct {
+		filter   Filter
+		expected string
+	}{
+		{Filter{}, "0x1 0x6"},
+		{Filter{Direction: DirectionIn}, "0x1"},
+		{Filter{Counterparty: "ONE1VALIDATOR"}, "0x6"},
+		{Filter{Until: time.Unix(6000, 0)}, "0x1"},
+		{Filter{Since: time.Unix(1001, 0)}, "0x6"},
+	}
+	for _, test := range tests {
+		if result := hashes(test.filter.Apply([]Transaction{delegation, received})); result != test.expected {
+			t.Errorf("Apply(%+v) returned %s, expected %s", test.filter, result, test.expected)
+		}
+	}
+}
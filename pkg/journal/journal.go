@@ -0,0 +1,233 @@
+package journal
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/intelchain-itc/itc-sdk/pkg/common"
+	"github.com/intelchain-itc/itc-sdk/pkg/history"
+)
+
+// The journal formats
+const (
+	Beancount = "beancount"
+	Ledger    = "ledger"
+
+	// Commodity is the commodity amounts are booked in
+	Commodity = "ITC"
+)
+
+var component = regexp.MustCompile(`^[A-Z][A-Za-z0-9-]*$`)
+
+// Accounts are the accounts transactions are booked to. The holdings of an
+// address are under Assets, one account per shard and one for its stake.
+type Accounts struct {
+	Assets   string
+	Received string
+	Sent     string
+	Fees     string
+	Rewards  string
+}
+
+// DefaultAccounts are the accounts booked to unless set otherwise
+var DefaultAccounts = Accounts{
+	Assets:   "Assets:ITC",
+	Received: "Income:ITC:Received",
+	Sent:     "Expenses:ITC:Sent",
+	Fees:     "Expenses:ITC:Fees",
+	Rewards:  "Income:ITC:StakingRewards",
+}
+
+// Posting is an amount in atto booked to an account
+type Posting struct {
+	Account string
+	Amount  *big.Int
+}
+
+// Entry is a journal entry of a transaction
+type Entry struct {
+	Date      string
+	Hash      string
+	Narration string
+	Postings  []Posting
+}
+
+// Balanced reports whether the postings of the entry sum to zero
+func (e Entry) Balanced() bool {
+	sum := new(big.Int)
+	for _, p := range e.Postings {
+		sum.Add(sum, p.Amount)
+	}
+	return sum.Sign() == 0
+}
+
+// Book books the transactions of a set of owned addresses
+type Book struct {
+	accounts Accounts
+	labels   map[string]string
+}
+
+// Label is the default account name component of an address
+func Label(address string) string {
+	return strings.ToUpper(address[:1]) + address[1:]
+}
+
+// NewBook books to accounts the transactions of the owned addresses, keyed by
+// address with the account name component of each
+func NewBook(accounts Accounts, owned map[string]string) (*Book, error) {
+	labels := map[string]string{}
+	for address, label := range owned {
+		if !component.MatchString(label) {
+			return nil, fmt.Errorf("label %s of %s is not an account name component", label, address)
+		}
+		labels[strings.ToLower(address)] = label
+	}
+	for _, account := range []string{accounts.Assets, accounts.Received, accounts.Sent, accounts.Fees, accounts.Rewards} {
+		for _, c := range strings.Split(account, ":") {
+			if !component.MatchString(c) {
+				return nil, fmt.Errorf("%s is not an account name", account)
+			}
+		}
+	}
+	return &Book{accounts, labels}, nil
+}
+
+func (b *Book) owns(address string) bool {
+	_, ok := b.labels[strings.ToLower(address)]
+	return ok
+}
+
+func (b *Book) liquid(address string, shard uint32) string {
+	return fmt.Sprintf("%s:%s:Shard%d", b.accounts.Assets, b.labels[strings.ToLower(address)], shard)
+}
+
+func (b *Book) staked(address string) string {
+	return fmt.Sprintf("%s:%s:Staked", b.accounts.Assets, b.labels[strings.ToLower(address)])
+}
+
+// Entries books the transactions, the first of each hash, leaving out those
+// that move nothing of the owned addresses
+func (b *Book) Entries(txs []history.Transaction) []Entry {
+	entries := []Entry{}
+	seen := map[string]bool{}
+	for _, t := range txs {
+		if seen[t.Hash] {
+			continue
+		}
+		seen[t.Hash] = true
+		if e, ok := b.entry(t); ok {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+func (b *Book) entry(t history.Transaction) (Entry, bool) {
+	e := Entry{Date: t.Time.UTC().Format("2006-01-02"), Hash: t.Hash}
+	post := func(account string, amount *big.Int) {
+		if amount.Sign() != 0 {
+			e.Postings = append(e.Postings, Posting{account, amount})
+		}
+	}
+	neg := func(amount *big.Int) *big.Int {
+		return new(big.Int).Neg(amount)
+	}
+	amount := t.Amount
+	if t.Status == history.StatusFailed {
+		amount = new(big.Int)
+	}
+	if b.owns(t.From) {
+		post(b.accounts.Fees, t.Fee)
+		post(b.liquid(t.From, t.Shard), neg(t.Fee))
+	}
+
+	switch {
+	case t.Kind == history.KindTransfer && b.owns(t.From) && b.owns(t.To):
+		e.Narration = fmt.Sprintf("Internal transfer from %s to %s", t.From, t.To)
+		post(b.liquid(t.From, t.Shard), neg(amount))
+		post(b.liquid(t.To, t.ToShard), amount)
+	case t.Kind == history.KindTransfer && b.owns(t.From):
+		e.Narration = fmt.Sprintf("Transfer to %s", t.To)
+		post(b.liquid(t.From, t.Shard), neg(amount))
+		post(b.accounts.Sent, amount)
+	case t.Kind == history.KindTransfer && b.owns(t.To):
+		e.Narration = fmt.Sprintf("Transfer from %s", t.From)
+		post(b.accounts.Received, neg(amount))
+		post(b.liquid(t.To, t.ToShard), amount)
+	case !b.owns(t.From):
+		return e, false
+	case t.Kind == "Delegate" || t.Kind == "CreateValidator":
+		e.Narration = fmt.Sprintf("%s to %s", t.Kind, t.To)
+		post(b.liquid(t.From, t.Shard), neg(amount))
+		post(b.staked(t.From), amount)
+	case t.Kind == "Undelegate":
+		e.Narration = fmt.Sprintf("Undelegate from %s", t.To)
+		post(b.staked(t.From), neg(amount))
+		post(b.liquid(t.From, t.Shard), amount)
+	case t.Kind == history.KindCollectRewards:
+		e.Narration = "Collect rewards"
+		post(b.accounts.Rewards, neg(amount))
+		post(b.liquid(t.From, t.Shard), amount)
+	default:
+		e.Narration = t.Kind
+	}
+	if t.Status == history.StatusFailed {
+		e.Narration += " (failed)"
+	}
+	return e, len(e.Postings) > 0
+}
+
+// Format formats an amount in atto as ITC
+func Format(amount *big.Int) string {
+	return common.FormatITC(amount) + " " + Commodity
+}
+
+// accountsOf are the accounts the entries post to, sorted
+func accountsOf(entries []Entry) []string {
+	seen := map[string]bool{}
+	accounts := []string{}
+	for _, e := range entries {
+		for _, p := range e.Postings {
+			if !seen[p.Account] {
+				seen[p.Account] = true
+				accounts = append(accounts, p.Account)
+			}
+		}
+	}
+	sort.Strings(accounts)
+	return accounts
+}
+
+// Write writes the entries to w as a beancount or ledger-cli journal. The
+// beancount journal opens its accounts on the date of the first entry.
+func Write(w io.Writer, format string, entries []Entry) error {
+	if format != Beancount && format != Ledger {
+		return fmt.Errorf("unknown journal format %s, expected %s or %s", format, Beancount, Ledger)
+	}
+	if format == Beancount && len(entries) > 0 {
+		for _, account := range accountsOf(entries) {
+			fmt.Fprintf(w, "%s open %s %s\n", entries[0].Date, account, Commodity)
+		}
+		fmt.Fprintln(w)
+	}
+	for _, e := range entries {
+		var err error
+		if format == Beancount {
+			_, err = fmt.Fprintf(w, "%s * %q\n  hash: %q\n", e.Date, e.Narration, e.Hash)
+		} else {
+			_, err = fmt.Fprintf(w, "%s * %s\n    ; hash: %s\n", strings.ReplaceAll(e.Date, "-", "/"), e.Narration, e.Hash)
+		}
+		if err != nil {
+			return err
+		}
+		for _, p := range e.Postings {
+			fmt.Fprintf(w, "    %-60s %s\n", p.Account, Format(p.Amount))
+		}
+		fmt.Fprintln(w)
+	}
+	return nil
+}
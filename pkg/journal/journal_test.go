@@ -0,0 +1,125 @@
+package journal
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/intelchain-itc/itc-sdk/pkg/history"
+)
+
+const (
+	treasury  = "one1treasury"
+	ops       = "one1ops"
+	customer  = "one1customer"
+	validator = "one1validator"
+)
+
+func itc(n int64) *big.Int {
+	return new(big.Int).Mul(big.NewInt(n), big.NewInt(1e18))
+}
+
+func tx(hash, kind, from, to string, shard, toShard uint32, amount *big.Int, status string) history.Transaction {
+	return history.Transaction{
+		Hash: hash, Kind: kind, From: from, To: to, Shard: shard, ToShard: toShard,
+		Time: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC), Amount: amount, Fee: big.NewInt(21e12), Status: status,
+	}
+}
+
+func TestEntries(t *testing.T) {
+	book, err := NewBook(DefaultAccounts, map[string]string{treasury: "Treasury", ops: Label(ops)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		tx       history.Transaction
+		expected string
+	}{
+		{tx("0x1", history.KindTransfer, customer, treasury, 1, 0, itc(100), history.StatusSuccess),
+			"Income:ITC:Received -100, Assets:ITC:Treasury:Shard0 100"},
+		{tx("0x2", history.KindTransfer, treasury, ops, 0, 1, itc(40), history.StatusSuccess),
+			"Expenses:ITC:Fees 0.000021, Assets:ITC:Treasury:Shard0 -0.000021, Assets:ITC:Treasury:Shard0 -40, Assets:ITC:One1ops:Shard1 40"},
+		{tx("0x3", history.KindTransfer, ops, customer, 1, 1, itc(5), history.StatusSuccess),
+			"Expenses:ITC:Fees 0.000021, Assets:ITC:One1ops:Shard1 -0.000021, Assets:ITC:One1ops:Shard1 -5, Expenses:ITC:Sent 5"},
+		{tx("0x4", history.KindTransfer, ops, customer, 1, 1, itc(5), history.StatusFailed),
+			"Expenses:ITC:Fees 0.000021, Assets:ITC:One1ops:Shard1 -0.000021"},
+		{tx("0x5", "Delegate", treasury, validator, 0, 0, itc(50), history.StatusSuccess),
+			"Expenses:ITC:Fees 0.000021, Assets:ITC:Treasury:Shard0 -0.000021, Assets:ITC:Treasury:Shard0 -50, Assets:ITC:Treasury:Staked 50"},
+		{tx("0x6", "Undelegate", treasury, validator, 0, 0, itc(20), history.StatusSuccess),
+			"Expenses:ITC:Fees 0.000021, Assets:ITC:Treasury:Shard0 -0.000021, Assets:ITC:Treasury:Staked -20, Assets:ITC:Treasury:Shard0 20"},
+		{tx("0x7", history.KindCollectRewards, treasury, "", 0, 0, itc(3), history.StatusSuccess),
+			"Expenses:ITC:Fees 0.000021, Assets:ITC:Treasury:Shard0 -0.000021, Income:ITC:StakingRewards -3, Assets:ITC:Treasury:Shard0 3"},
+		{tx("0x8", history.KindTransfer, customer, treasury, 0, 0, itc(1), history.StatusFailed), ""},
+	}
+	for _, test := range tests {
+		postings := []string{}
+		for _, e := range book.Entries([]history.Transaction{test.tx}) {
+			if !e.Balanced() {
+				t.Errorf("Entries(%s) returned unbalanced %+v", test.tx.Hash, e)
+			}
+			for _, p := range e.Postings {
+				postings = append(postings, p.Account+" "+strings.TrimSuffix(Format(p.Amount), " ITC"))
+			}
+		}
+		if result := strings.Join(postings, ", "); result != test.expected {
+			t.Errorf("Entries(%s) returned %s, expected %s", test.tx.Hash, result, test.expected)
+		}
+	}
+
+	if _, err := NewBook(DefaultAccounts, map[string]string{ops: "ops"}); err == nil {
+		t.Errorf("NewBook with label ops returned no error")
+	}
+	accounts := DefaultAccounts
+	accounts.Assets = "Assets:itc"
+	if _, err := NewBook(accounts, nil); err == nil {
+		t.Errorf("NewBook with account Assets:itc returned no error")
+	}
+}
+
+func TestWrite(t *testing.T) {
+	book, err := NewBook(DefaultAccounts, map[string]string{treasury: "Treasury"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	txs := []history.Transaction{
+		tx("0x1", history.KindTransfer, customer, treasury, 0, 0, itc(100), history.StatusSuccess),
+		tx("0x1", history.KindTransfer, customer, treasury, 0, 0, itc(100), history.StatusSuccess),
+	}
+	entries := book.Entries(txs)
+	if len(entries) != 1 {
+		t.Fatalf("Entries returned %d entries, expected 1", len(entries))
+	}
+
+	tests := []struct {
+		format   string
+		expected []string
+	}{
+		{Beancount, []string{
+			"2026-03-01 open Assets:ITC:Treasury:Shard0 ITC\n2026-03-01 open Income:ITC:Received ITC\n",
+			"2026-03-01 * \"Transfer from one1customer\"\n  hash: \"0x1\"\n",
+			"    Income:ITC:Received",
+			"-100 ITC\n",
+		}},
+		{Ledger, []string{
+			"2026/03/01 * Transfer from one1customer\n    ; hash: 0x1\n",
+			"    Assets:ITC:Treasury:Shard0",
+			" 100 ITC\n",
+		}},
+	}
+	for _, test := range tests {
+		out := bytes.Buffer{}
+		if err := Write(&out, test.format, entries); err != nil {
+			t.Fatalf("Write(%s) returned error %v", test.format, err)
+		}
+		for _, expected := range test.expected {
+			if !strings.Contains(out.String(), expected) {
+				t.Errorf("Write(%s) returned %q, expected it to contain %q", test.format, out.String(), expected)
+			}
+		}
+	}
+	if err := Write(&bytes.Buffer{}, "hledger", entries); err == nil {
+		t.Errorf("Write(hledger) returned no error")
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/intelchain-itc/intelchain/crypto/hash"
 	"github.com/intelchain-itc/intelchain/staking/types"
 	"github.com/intelchain-itc/itc-sdk/pkg/common"
+	"github.com/intelchain-itc/itc-sdk/pkg/mnemonic"
 	"github.com/intelchain-itc/itc-sdk/pkg/sharding"
 	"github.com/intelchain-itc/itc-sdk/pkg/validation"
 	"golang.org/x/crypto/ssh/terminal"
@@ -35,24 +36,65 @@ type BlsKey struct {
 	Passphrase     string
 	FilePath       string
 	ShardPublicKey *bls.SerializedPublicKey
+	// DerivationPath is the path of a key derived from a mnemonic
+	DerivationPath string
+}
+
+// blsKeySource yields the candidate private keys genBlsKeyForNode picks
+// from, along with their derivation path if any
+type blsKeySource func() (*bls_core.SecretKey, string, error)
+
+func randomBlsKeys() (*bls_core.SecretKey, string, error) {
+	return bls.RandPrivateKey(), "", nil
+}
+
+// mnemonicBlsKeys derives the keys of the mnemonic at consecutive indices,
+// so the keys picked for a shard layout only depend on the seed phrase
+func mnemonicBlsKeys(m, passphrase string) blsKeySource {
+	seed := mnemonic.Seed(m, passphrase)
+	index := uint32(0)
+	return func() (*bls_core.SecretKey, string, error) {
+		path := BlsPath(index)
+		index++
+		privateKey, err := BlsKeyFromSeed(seed, path)
+		return privateKey, path, err
+	}
+}
+
+// BlsKeyFromSeed derives the EIP-2333 bls private key of a bip39 seed at path
+func BlsKeyFromSeed(seed []byte, path string) (*bls_core.SecretKey, error) {
+	secret, err := DeriveBlsSecretAtPath(seed, path)
+	if err != nil {
+		return nil, err
+	}
+	privateKey := &bls_core.SecretKey{}
+	if err := privateKey.SetDecString(secret.String()); err != nil {
+		return nil, err
+	}
+	return privateKey, nil
 }
 
 // Initialize - initialize a bls key and assign a random private bls key if not already done
 func (blsKey *BlsKey) Initialize() {
 	if blsKey.PrivateKey == nil {
-		blsKey.PrivateKey = bls.RandPrivateKey()
-		blsKey.PrivateKeyHex = blsKey.PrivateKey.SerializeToHexStr()
-		blsKey.PublicKey = blsKey.PrivateKey.GetPublicKey()
-		blsKey.PublicKeyHex = blsKey.PublicKey.SerializeToHexStr()
+		blsKey.setPrivateKey(bls.RandPrivateKey())
 	}
 }
 
+func (blsKey *BlsKey) setPrivateKey(privateKey *bls_core.SecretKey) {
+	blsKey.PrivateKey = privateKey
+	blsKey.PrivateKeyHex = privateKey.SerializeToHexStr()
+	blsKey.PublicKey = privateKey.GetPublicKey()
+	blsKey.PublicKeyHex = blsKey.PublicKey.SerializeToHexStr()
+}
+
 // Reset - resets the currently assigned private and public key fields
 func (blsKey *BlsKey) Reset() {
 	blsKey.PrivateKey = nil
 	blsKey.PrivateKeyHex = ""
 	blsKey.PublicKey = nil
 	blsKey.PublicKeyHex = ""
+	blsKey.DerivationPath = ""
 }
 
 // GenBlsKey - generate a random bls key using the supplied passphrase, write it to disk at the given filePath
@@ -68,7 +110,17 @@ func GenBlsKey(blsKey *BlsKey) error {
 
 // GenMultiBlsKeys - generate multiple BLS keys for a given shard and node/network
 func GenMultiBlsKeys(blsKeys []*BlsKey, node string, shardID uint32) error {
-	blsKeys, _, err := genBlsKeyForNode(blsKeys, node, shardID)
+	return genAndWriteBlsKeys(blsKeys, node, shardID, randomBlsKeys)
+}
+
+// GenMnemonicBlsKeys - derive BLS keys for a given shard and node/network from a mnemonic
+// and its optional bip39 passphrase, the same mnemonic and shard layout always give the same keys
+func GenMnemonicBlsKeys(blsKeys []*BlsKey, m, bip39Passphrase, node string, shardID uint32) error {
+	return genAndWriteBlsKeys(blsKeys, node, shardID, mnemonicBlsKeys(m, bip39Passphrase))
+}
+
+func genAndWriteBlsKeys(blsKeys []*BlsKey, node string, shardID uint32, source blsKeySource) error {
+	blsKeys, _, err := genBlsKeyForNode(blsKeys, node, shardID, source)
 	if err != nil {
 		return err
 	}
@@ -94,11 +146,7 @@ func RecoverBlsKeyFromFile(passphrase, filePath string) error {
 	if err != nil {
 		return err
 	}
-	decryptedPrivateKeyBytes, err := decrypt(encryptedPrivateKeyBytes, passphrase)
-	if err != nil {
-		return err
-	}
-	privateKey, err := getBlsKey(string(decryptedPrivateKeyBytes))
+	privateKey, err := blsKeyFromFile(encryptedPrivateKeyBytes, passphrase)
 	if err != nil {
 		return err
 	}
@@ -113,6 +161,47 @@ func RecoverBlsKeyFromFile(passphrase, filePath string) error {
 
 }
 
+// MigrateBlsKeyFile re-encrypts a legacy bls key file in place with the
+// versioned format, keeping the legacy file next to it as a backup. It
+// returns the path of the backup.
+func MigrateBlsKeyFile(passphrase, filePath string) (string, error) {
+	if !path.IsAbs(filePath) {
+		return "", common.ErrNotAbsPath
+	}
+	legacy, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return "", err
+	}
+	if isVersionedBlsKeyFile(legacy) {
+		return "", ErrBlsKeyFileMigrated
+	}
+	privateKey, err := blsKeyFromFile(legacy, passphrase)
+	if err != nil {
+		return "", err
+	}
+	privateKeyHex := privateKey.SerializeToHexStr()
+	publicKeyHex := privateKey.GetPublicKey().SerializeToHexStr()
+	sealed, err := sealBlsKey(privateKeyHex, publicKeyHex, passphrase, common.ScryptN, common.ScryptP)
+	if err != nil {
+		return "", err
+	}
+	// make sure the new file opens to the same key before touching the old one
+	if reopened, err := openBlsKey(sealed, passphrase); err != nil || reopened != privateKeyHex {
+		return "", fmt.Errorf("could not verify re-encrypted bls key: %v", err)
+	}
+	backupPath := filePath + ".legacy"
+	if _, err := os.Stat(backupPath); err == nil {
+		return "", fmt.Errorf("backup %s already exists", backupPath)
+	}
+	if err := ioutil.WriteFile(backupPath, legacy, 0600); err != nil {
+		return "", err
+	}
+	if err := writeFileAtomic(filePath, sealed); err != nil {
+		return "", err
+	}
+	return backupPath, nil
+}
+
 func SaveBlsKey(passphrase, filePath, privateKeyHex string) error {
 	privateKeyHex = strings.TrimPrefix(privateKeyHex, "0x")
 	privateKey, err := getBlsKey(privateKeyHex)
@@ -126,11 +215,13 @@ func SaveBlsKey(passphrase, filePath, privateKeyHex string) error {
 	if !path.IsAbs(filePath) {
 		return common.ErrNotAbsPath
 	}
-	encryptedPrivateKeyStr, err := encrypt([]byte(privateKeyHex), passphrase)
+	sealed, err := sealBlsKey(
+		privateKeyHex, privateKey.GetPublicKey().SerializeToHexStr(), passphrase, common.ScryptN, common.ScryptP,
+	)
 	if err != nil {
 		return err
 	}
-	err = writeToFile(filePath, encryptedPrivateKeyStr)
+	err = writeToFile(filePath, string(sealed))
 	if err != nil {
 		return err
 	}
@@ -208,11 +299,7 @@ func VerifyBLS(blsPubKey string, blsPubKeyDir string) (bls.SerializedSignature,
 
 	cleanPass := strings.TrimSpace(string(pass))
 	cleanPass = strings.ReplaceAll(cleanPass, "\t", "")
-	decryptedPrivateKeyBytes, err := decrypt(encryptedPrivateKeyBytes, cleanPass)
-	if err != nil {
-		return sig, err
-	}
-	privateKey, err := getBlsKey(string(decryptedPrivateKeyBytes))
+	privateKey, err := blsKeyFromFile(encryptedPrivateKeyBytes, cleanPass)
 	if err != nil {
 		return sig, err
 	}
@@ -244,6 +331,26 @@ func getBlsKey(privateKeyHex string) (*bls_core.SecretKey, error) {
 	return privateKey, nil
 }
 
+// blsKeyFromFile decrypts a bls key file in either format, checking the
+// cleartext public key of versioned files against the decrypted key
+func blsKeyFromFile(data []byte, passphrase string) (*bls_core.SecretKey, error) {
+	privateKeyHex, err := openBlsKey(data, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	privateKey, err := getBlsKey(privateKeyHex)
+	if err != nil {
+		return nil, err
+	}
+	if isVersionedBlsKeyFile(data) {
+		publicKeyHex, _ := BlsKeyFilePublicKey(data)
+		if publicKeyHex != privateKey.GetPublicKey().SerializeToHexStr() {
+			return nil, errors.New("bls key file public key does not match its private key")
+		}
+	}
+	return privateKey, nil
+}
+
 func writeBlsKeyToFile(blsKey *BlsKey) (string, error) {
 	if blsKey.FilePath == "" {
 		cwd, _ := os.Getwd()
@@ -252,17 +359,24 @@ func writeBlsKeyToFile(blsKey *BlsKey) (string, error) {
 	if !path.IsAbs(blsKey.FilePath) {
 		return "", common.ErrNotAbsPath
 	}
-	encryptedPrivateKeyStr, err := encrypt([]byte(blsKey.PrivateKeyHex), blsKey.Passphrase)
+	sealed, err := sealBlsKey(
+		blsKey.PrivateKeyHex, blsKey.PublicKeyHex, blsKey.Passphrase, common.ScryptN, common.ScryptP,
+	)
 	if err != nil {
 		return "", err
 	}
-	err = writeToFile(blsKey.FilePath, encryptedPrivateKeyStr)
+	err = writeToFile(blsKey.FilePath, string(sealed))
 	if err != nil {
 		return "", err
 	}
 	out := fmt.Sprintf(`
 {"public-key" : "%s", "private-key" : "%s", "encrypted-private-key-path" : "%s"}`,
 		blsKey.PublicKeyHex, blsKey.PrivateKeyHex, blsKey.FilePath)
+	if blsKey.DerivationPath != "" {
+		out = fmt.Sprintf(`
+{"public-key" : "%s", "private-key" : "%s", "encrypted-private-key-path" : "%s", "derivation-path" : "%s"}`,
+			blsKey.PublicKeyHex, blsKey.PrivateKeyHex, blsKey.FilePath, blsKey.DerivationPath)
+	}
 
 	return out, nil
 }
@@ -334,7 +448,9 @@ func decryptRaw(data []byte, passphrase string) ([]byte, error) {
 	return plaintext, err
 }
 
-func genBlsKeyForNode(blsKeys []*BlsKey, node string, shardID uint32) ([]*BlsKey, int, error) {
+func genBlsKeyForNode(
+	blsKeys []*BlsKey, node string, shardID uint32, source blsKeySource,
+) ([]*BlsKey, int, error) {
 	shardingStructure, err := sharding.Structure(node)
 	if err != nil {
 		return blsKeys, -1, err
@@ -347,7 +463,12 @@ func genBlsKeyForNode(blsKeys []*BlsKey, node string, shardID uint32) ([]*BlsKey
 
 	for _, blsKey := range blsKeys {
 		for {
-			blsKey.Initialize()
+			privateKey, derivationPath, err := source()
+			if err != nil {
+				return blsKeys, shardCount, err
+			}
+			blsKey.setPrivateKey(privateKey)
+			blsKey.DerivationPath = derivationPath
 			shardPubKey := new(bls.SerializedPublicKey)
 			if err = shardPubKey.FromLibBLSPublicKey(blsKey.PublicKey); err != nil {
 				return blsKeys, shardCount, err
@@ -59,7 +59,7 @@ func TestMultiBlsKeyGeneration(t *testing.T) {
 			blsKeys = append(blsKeys, &BlsKey{Passphrase: "", FilePath: ""})
 		}
 
-		blsKeys, shardCount, err := genBlsKeyForNode(blsKeys, test.node, test.shardID)
+		blsKeys, shardCount, err := genBlsKeyForNode(blsKeys, test.node, test.shardID, randomBlsKeys)
 		if err != nil {
 			valid = false
 		}
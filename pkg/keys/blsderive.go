@@ -0,0 +1,116 @@
+package keys
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	// blsLamportChunks is the number of 32 byte chunks in each half of a
+	// lamport key, one per bit of a 255 bit secret
+	blsLamportChunks = 255
+	blsKeyGenSalt    = "BLS-SIG-KEYGEN-SALT-"
+)
+
+var (
+	// blsCurveOrder is the order r of the BLS12-381 subgroup
+	blsCurveOrder, _ = new(big.Int).SetString(
+		"73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001", 16,
+	)
+)
+
+// BlsPath returns the EIP-2334 style path m/12381/1023/index/0 of the
+// index-th bls signing key, using the intelchain coin type
+func BlsPath(index uint32) string {
+	return fmt.Sprintf("m/12381/%d/%d/0", IntelchainCoinType, index)
+}
+
+// hkdfModR turns key material into a non-zero secret below the curve order
+func hkdfModR(ikm []byte) *big.Int {
+	const l = 48
+	salt := []byte(blsKeyGenSalt)
+	secret := new(big.Int)
+	for secret.Sign() == 0 {
+		hash := sha256.Sum256(salt)
+		salt = hash[:]
+		prk := hkdf.Extract(sha256.New, append(append([]byte{}, ikm...), 0), salt)
+		okm := make([]byte, l)
+		io.ReadFull(hkdf.Expand(sha256.New, prk, []byte{0, l}), okm)
+		secret.Mod(new(big.Int).SetBytes(okm), blsCurveOrder)
+	}
+	return secret
+}
+
+// ikmToLamportSecret expands key material into the chunks of one half of a
+// lamport secret key
+func ikmToLamportSecret(ikm, salt []byte) [][]byte {
+	prk := hkdf.Extract(sha256.New, ikm, salt)
+	okm := make([]byte, 32*blsLamportChunks)
+	io.ReadFull(hkdf.Expand(sha256.New, prk, nil), okm)
+	chunks := make([][]byte, blsLamportChunks)
+	for i := range chunks {
+		chunks[i] = okm[32*i : 32*(i+1)]
+	}
+	return chunks
+}
+
+// parentSecretToLamportPublic computes the compressed lamport public key a
+// child secret is derived from
+func parentSecretToLamportPublic(parent *big.Int, index uint32) []byte {
+	salt := make([]byte, 4)
+	binary.BigEndian.PutUint32(salt, index)
+	ikm := parent.FillBytes(make([]byte, 32))
+	notIKM := make([]byte, len(ikm))
+	for i, b := range ikm {
+		notIKM[i] = ^b
+	}
+	hasher := sha256.New()
+	for _, half := range [][]byte{ikm, notIKM} {
+		for _, chunk := range ikmToLamportSecret(half, salt) {
+			hash := sha256.Sum256(chunk)
+			hasher.Write(hash[:])
+		}
+	}
+	return hasher.Sum(nil)
+}
+
+// DeriveBlsMasterSecret derives the EIP-2333 master secret of a seed
+func DeriveBlsMasterSecret(seed []byte) (*big.Int, error) {
+	if len(seed) < 32 {
+		return nil, fmt.Errorf("seed must be at least 32 bytes, got %d", len(seed))
+	}
+	return hkdfModR(seed), nil
+}
+
+// DeriveBlsChildSecret derives the EIP-2333 child secret at index of parent
+func DeriveBlsChildSecret(parent *big.Int, index uint32) *big.Int {
+	return hkdfModR(parentSecretToLamportPublic(parent, index))
+}
+
+// DeriveBlsSecretAtPath derives the EIP-2333 secret of a seed at a path such
+// as m/12381/1023/0/0, where every index is a hardened child
+func DeriveBlsSecretAtPath(seed []byte, path string) (*big.Int, error) {
+	parts := strings.Split(strings.TrimSpace(path), "/")
+	if parts[0] != "m" {
+		return nil, fmt.Errorf("%w: %q must start with m", ErrInvalidHDPath, path)
+	}
+	secret, err := DeriveBlsMasterSecret(seed)
+	if err != nil {
+		return nil, err
+	}
+	for _, part := range parts[1:] {
+		index, err := strconv.ParseUint(part, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q has a bad component %q", ErrInvalidHDPath, path, part)
+		}
+		secret = DeriveBlsChildSecret(secret, uint32(index))
+	}
+	return secret, nil
+}
@@ -0,0 +1,58 @@
+package keys
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestDeriveBlsChildSecret(t *testing.T) {
+	// test cases from EIP-2333
+	tests := []struct {
+		seed     string
+		master   string
+		index    uint32
+		expected string
+	}{
+		{
+			"c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+			"6083874454709270928345386274498605044986640685124978867557563392430687146096",
+			0,
+			"20397789859736650942317412262472558107875392172444076792671091975210932703118",
+		},
+		{
+			"3141592653589793238462643383279502884197169399375105820974944592",
+			"29757020647961307431480504535336562678282505419141012933316116377660817309383",
+			3141592653,
+			"25457201688850691947727629385191704516744796114925897962676248250929345014287",
+		},
+	}
+
+	for _, test := range tests {
+		seed, _ := hex.DecodeString(test.seed)
+		master, err := DeriveBlsMasterSecret(seed)
+		if err != nil {
+			t.Errorf("DeriveBlsMasterSecret(%s) failed %v", test.seed, err)
+			continue
+		}
+		if master.String() != test.master {
+			t.Errorf("DeriveBlsMasterSecret(%s) returned %s, expected %s", test.seed, master, test.master)
+		}
+		if child := DeriveBlsChildSecret(master, test.index); child.String() != test.expected {
+			t.Errorf("DeriveBlsChildSecret(%d) returned %s, expected %s", test.index, child, test.expected)
+		}
+	}
+}
+
+func TestDeriveBlsSecretAtPath(t *testing.T) {
+	seed, _ := hex.DecodeString("3141592653589793238462643383279502884197169399375105820974944592")
+	master, _ := DeriveBlsMasterSecret(seed)
+	expected := DeriveBlsChildSecret(DeriveBlsChildSecret(master, 12381), 1023)
+	if secret, err := DeriveBlsSecretAtPath(seed, "m/12381/1023"); err != nil || secret.Cmp(expected) != 0 {
+		t.Errorf("DeriveBlsSecretAtPath returned %v %v, expected %s", secret, err, expected)
+	}
+	for _, path := range []string{"12381/1023", "m/12381/x", "m/12381/4294967296"} {
+		if _, err := DeriveBlsSecretAtPath(seed, path); err == nil {
+			t.Errorf("DeriveBlsSecretAtPath(%s) succeeded, expected an error", path)
+		}
+	}
+}
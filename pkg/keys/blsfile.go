@@ -0,0 +1,221 @@
+package keys
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// BlsKeyFileVersion is the version of the bls key file format written
+	// by the sdk, files without a version are legacy AES keyed by an MD5 hash
+	BlsKeyFileVersion = 2
+
+	blsKeyCipher  = "aes-256-gcm"
+	blsKeyKDF     = "scrypt"
+	blsKeyDKLen   = 64
+	blsKeyScryptR = 8
+)
+
+var (
+	ErrBlsKeyFileVersion  = errors.New("unsupported bls key file version")
+	ErrBlsKeyFileMAC      = errors.New("could not decrypt bls key file with given passphrase")
+	ErrBlsKeyFileMigrated = errors.New("bls key file already uses the current format")
+)
+
+// blsKeyFile is the versioned bls key file, its public key is in cleartext
+// so files can be identified without the passphrase
+type blsKeyFile struct {
+	Version   int          `json:"version"`
+	PublicKey string       `json:"public-key"`
+	Crypto    blsKeyCrypto `json:"crypto"`
+}
+
+type blsKeyCrypto struct {
+	Cipher     string          `json:"cipher"`
+	CipherText string          `json:"ciphertext"`
+	Nonce      string          `json:"nonce"`
+	KDF        string          `json:"kdf"`
+	KDFParams  blsKeyKDFParams `json:"kdfparams"`
+	MAC        string          `json:"mac"`
+}
+
+type blsKeyKDFParams struct {
+	N     int    `json:"n"`
+	R     int    `json:"r"`
+	P     int    `json:"p"`
+	DKLen int    `json:"dklen"`
+	Salt  string `json:"salt"`
+}
+
+// blsKeyMAC binds the public key, nonce and ciphertext to the second half of
+// the derived key, telling a wrong passphrase apart from a corrupted file
+func blsKeyMAC(macKey []byte, publicKeyHex string, nonce, ciphertext []byte) []byte {
+	mac := hmac.New(sha256.New, macKey)
+	mac.Write([]byte(publicKeyHex))
+	mac.Write(nonce)
+	mac.Write(ciphertext)
+	return mac.Sum(nil)
+}
+
+// sealBlsKey encrypts the hex of a bls private key into a versioned key file
+func sealBlsKey(privateKeyHex, publicKeyHex, passphrase string, scryptN, scryptP int) ([]byte, error) {
+	salt := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, err
+	}
+	derived, err := scrypt.Key([]byte(passphrase), salt, scryptN, blsKeyScryptR, scryptP, blsKeyDKLen)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(derived[:32])
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	ciphertext := gcm.Seal(nil, nonce, []byte(privateKeyHex), []byte(publicKeyHex))
+	return json.MarshalIndent(blsKeyFile{
+		Version:   BlsKeyFileVersion,
+		PublicKey: publicKeyHex,
+		Crypto: blsKeyCrypto{
+			Cipher:     blsKeyCipher,
+			CipherText: hex.EncodeToString(ciphertext),
+			Nonce:      hex.EncodeToString(nonce),
+			KDF:        blsKeyKDF,
+			KDFParams: blsKeyKDFParams{
+				N: scryptN, R: blsKeyScryptR, P: scryptP, DKLen: blsKeyDKLen, Salt: hex.EncodeToString(salt),
+			},
+			MAC: hex.EncodeToString(blsKeyMAC(derived[32:], publicKeyHex, nonce, ciphertext)),
+		},
+	}, "", "  ")
+}
+
+// parseBlsKeyFile reads a versioned key file, ok is false for legacy files,
+// whose raw nonce and ciphertext may well start with "{" but do not hold JSON
+// with a version
+func parseBlsKeyFile(data []byte) (file blsKeyFile, ok bool) {
+	if err := json.Unmarshal(data, &file); err != nil || file.Version == 0 {
+		return blsKeyFile{}, false
+	}
+	return file, true
+}
+
+// isVersionedBlsKeyFile tells the JSON key files apart from legacy ones,
+// which hold nothing but hex or raw ciphertext
+func isVersionedBlsKeyFile(data []byte) bool {
+	_, ok := parseBlsKeyFile(data)
+	return ok
+}
+
+// BlsKeyFilePublicKey returns the cleartext public key of a versioned bls key
+// file, legacy files do not have one
+func BlsKeyFilePublicKey(data []byte) (string, error) {
+	file, ok := parseBlsKeyFile(data)
+	if !ok {
+		return "", fmt.Errorf("%w: legacy file has no public key", ErrBlsKeyFileVersion)
+	}
+	return file.PublicKey, nil
+}
+
+// openBlsKey decrypts the hex of the bls private key from a key file in
+// either the versioned or the legacy format
+func openBlsKey(data []byte, passphrase string) (string, error) {
+	file, ok := parseBlsKeyFile(data)
+	if !ok {
+		decrypted, err := decrypt(data, passphrase)
+		if err != nil {
+			return "", err
+		}
+		return string(decrypted), nil
+	}
+	c := file.Crypto
+	if file.Version != BlsKeyFileVersion || c.Cipher != blsKeyCipher || c.KDF != blsKeyKDF ||
+		c.KDFParams.DKLen != blsKeyDKLen {
+		return "", fmt.Errorf(
+			"%w: version %d with %s and %s", ErrBlsKeyFileVersion, file.Version, c.Cipher, c.KDF,
+		)
+	}
+	salt, err := hex.DecodeString(c.KDFParams.Salt)
+	if err != nil {
+		return "", err
+	}
+	nonce, err := hex.DecodeString(c.Nonce)
+	if err != nil {
+		return "", err
+	}
+	ciphertext, err := hex.DecodeString(c.CipherText)
+	if err != nil {
+		return "", err
+	}
+	mac, err := hex.DecodeString(c.MAC)
+	if err != nil {
+		return "", err
+	}
+	derived, err := scrypt.Key([]byte(passphrase), salt, c.KDFParams.N, c.KDFParams.R, c.KDFParams.P, c.KDFParams.DKLen)
+	if err != nil {
+		return "", err
+	}
+	if !hmac.Equal(mac, blsKeyMAC(derived[32:], file.PublicKey, nonce, ciphertext)) {
+		return "", ErrBlsKeyFileMAC
+	}
+	block, err := aes.NewCipher(derived[:32])
+	if err != nil {
+		return "", err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+	if len(nonce) != gcm.NonceSize() {
+		return "", fmt.Errorf("bad nonce length %d", len(nonce))
+	}
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, []byte(file.PublicKey))
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// writeFileAtomic replaces filename with data through a temporary file in
+// the same directory, so a crash never leaves half a key file behind
+func writeFileAtomic(filename string, data []byte) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(filename), "."+filepath.Base(filename)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filename)
+}
@@ -0,0 +1,68 @@
+package keys
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+const (
+	testBlsPrivateKeyHex = "1f84c95ac16e6a50f08d44c7bde7aff8742212fda6e4321fde48bf83bef266dc"
+	testBlsPublicKeyHex  = "0b3af4c0e9f5b3e4f7c1d29d0b9c9a0c6c5d3a1e0a6ad0bd4a3fc9fbd5d1e1e4"
+	testScryptN          = 1 << 4
+)
+
+func TestOpenBlsKey(t *testing.T) {
+	sealed, err := sealBlsKey(testBlsPrivateKeyHex, testBlsPublicKeyHex, "foo", testScryptN, 1)
+	if err != nil {
+		t.Fatalf("sealBlsKey failed %v", err)
+	}
+	legacy, err := encrypt([]byte(testBlsPrivateKeyHex), "foo")
+	if err != nil {
+		t.Fatalf("encrypt failed %v", err)
+	}
+	// raw legacy files are nonce and ciphertext, which may start with "{"
+	braced := []byte{}
+	for len(braced) == 0 || braced[0] != '{' {
+		legacyHex, err := encrypt([]byte(testBlsPrivateKeyHex), "foo")
+		if err != nil {
+			t.Fatalf("encrypt failed %v", err)
+		}
+		braced, _ = hex.DecodeString(legacyHex)
+	}
+	tampered := bytes.Replace(sealed, []byte(testBlsPublicKeyHex), []byte(testBlsPrivateKeyHex), 1)
+	future := bytes.Replace(sealed, []byte(`"version": 2`), []byte(`"version": 3`), 1)
+
+	tests := []struct {
+		name        string
+		data        []byte
+		passphrase  string
+		expectedErr error
+	}{
+		{"versioned", sealed, "foo", nil},
+		{"versioned with wrong passphrase", sealed, "bar", ErrBlsKeyFileMAC},
+		{"versioned with tampered public key", tampered, "foo", ErrBlsKeyFileMAC},
+		{"future version", future, "foo", ErrBlsKeyFileVersion},
+		{"legacy", []byte(legacy), "foo", nil},
+		{"raw legacy starting with a brace", braced, "foo", nil},
+	}
+
+	for _, test := range tests {
+		privateKeyHex, err := openBlsKey(test.data, test.passphrase)
+		if !errors.Is(err, test.expectedErr) {
+			t.Errorf("openBlsKey(%s) returned error %v, expected %v", test.name, err, test.expectedErr)
+			continue
+		}
+		if err == nil && privateKeyHex != testBlsPrivateKeyHex {
+			t.Errorf("openBlsKey(%s) returned %s, expected %s", test.name, privateKeyHex, testBlsPrivateKeyHex)
+		}
+	}
+
+	if publicKeyHex, err := BlsKeyFilePublicKey(sealed); err != nil || publicKeyHex != testBlsPublicKeyHex {
+		t.Errorf("BlsKeyFilePublicKey returned %s %v, expected %s", publicKeyHex, err, testBlsPublicKeyHex)
+	}
+	if _, err := BlsKeyFilePublicKey([]byte(legacy)); !errors.Is(err, ErrBlsKeyFileVersion) {
+		t.Errorf("BlsKeyFilePublicKey of legacy file returned %v, expected %v", err, ErrBlsKeyFileVersion)
+	}
+}
@@ -0,0 +1,106 @@
+package keys
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	bls_core "github.com/intelchain-itc/bls/ffi/go/bls"
+	"github.com/intelchain-itc/intelchain/crypto/hash"
+)
+
+var (
+	ErrBlsSignatureInvalid = errors.New("bls signature does not verify")
+	ErrBlsNothingToCombine = errors.New("nothing to aggregate")
+)
+
+// BlsMessageHash returns the hash a bls signature is made over. A message
+// flagged as a hash must be 32 bytes and is used as is, any other message is
+// hashed with keccak256 like the staking bls key verification.
+func BlsMessageHash(message []byte, isHash bool) ([]byte, error) {
+	if isHash {
+		if len(message) != 32 {
+			return nil, fmt.Errorf("hash must be 32 bytes, got %d", len(message))
+		}
+		return message, nil
+	}
+	msgHash := hash.Keccak256(message)
+	return msgHash[:], nil
+}
+
+// BlsSign signs the message with the key of a bls key file in either
+// format, returning the hex of the signature and of the public key
+func BlsSign(keyFile []byte, passphrase string, message []byte, isHash bool) (string, string, error) {
+	privateKey, err := blsKeyFromFile(keyFile, passphrase)
+	if err != nil {
+		return "", "", err
+	}
+	msgHash, err := BlsMessageHash(message, isHash)
+	if err != nil {
+		return "", "", err
+	}
+	signature := privateKey.SignHash(msgHash)
+	return signature.SerializeToHexStr(), privateKey.GetPublicKey().SerializeToHexStr(), nil
+}
+
+// BlsVerify checks a signature of the message against a public key, either
+// of which can be an aggregate
+func BlsVerify(publicKeyHex, signatureHex string, message []byte, isHash bool) error {
+	publicKey := &bls_core.PublicKey{}
+	if err := publicKey.DeserializeHexStr(strings.TrimPrefix(publicKeyHex, "0x")); err != nil {
+		return fmt.Errorf("bad bls public key: %w", err)
+	}
+	signature := &bls_core.Sign{}
+	if err := signature.DeserializeHexStr(strings.TrimPrefix(signatureHex, "0x")); err != nil {
+		return fmt.Errorf("bad bls signature: %w", err)
+	}
+	msgHash, err := BlsMessageHash(message, isHash)
+	if err != nil {
+		return err
+	}
+	if !signature.VerifyHash(publicKey, msgHash) {
+		return ErrBlsSignatureInvalid
+	}
+	return nil
+}
+
+// BlsAggregateSignatures combines signatures of the same message into one,
+// which verifies against the aggregate of their public keys
+func BlsAggregateSignatures(signatureHexes []string) (string, error) {
+	if len(signatureHexes) == 0 {
+		return "", ErrBlsNothingToCombine
+	}
+	var aggregate *bls_core.Sign
+	for i, signatureHex := range signatureHexes {
+		signature := &bls_core.Sign{}
+		if err := signature.DeserializeHexStr(strings.TrimPrefix(signatureHex, "0x")); err != nil {
+			return "", fmt.Errorf("bad bls signature #%d: %w", i+1, err)
+		}
+		if i == 0 {
+			aggregate = signature
+			continue
+		}
+		aggregate.Add(signature)
+	}
+	return aggregate.SerializeToHexStr(), nil
+}
+
+// BlsAggregatePublicKeys combines public keys into one
+func BlsAggregatePublicKeys(publicKeyHexes []string) (string, error) {
+	if len(publicKeyHexes) == 0 {
+		return "", ErrBlsNothingToCombine
+	}
+	var aggregate *bls_core.PublicKey
+	for i, publicKeyHex := range publicKeyHexes {
+		publicKey := &bls_core.PublicKey{}
+		if err := publicKey.DeserializeHexStr(strings.TrimPrefix(publicKeyHex, "0x")); err != nil {
+			return "", fmt.Errorf("bad bls public key #%d: %w", i+1, err)
+		}
+		if i == 0 {
+			aggregate = publicKey
+			continue
+		}
+		aggregate.Add(publicKey)
+	}
+	return aggregate.SerializeToHexStr(), nil
+}
@@ -0,0 +1,62 @@
+package keys
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/intelchain-itc/intelchain/crypto/bls"
+)
+
+func TestBlsSignAndAggregate(t *testing.T) {
+	message := []byte("itc bls ownership proof")
+	signatures, publicKeys := []string{}, []string{}
+	for i := 0; i < 2; i++ {
+		privateKey := bls.RandPrivateKey()
+		keyFile, err := sealBlsKey(
+			privateKey.SerializeToHexStr(), privateKey.GetPublicKey().SerializeToHexStr(), "foo", testScryptN, 1,
+		)
+		if err != nil {
+			t.Fatalf("sealBlsKey failed %v", err)
+		}
+		signature, publicKey, err := BlsSign(keyFile, "foo", message, false)
+		if err != nil {
+			t.Fatalf("BlsSign failed %v", err)
+		}
+		signatures, publicKeys = append(signatures, signature), append(publicKeys, publicKey)
+	}
+	aggregateSignature, err := BlsAggregateSignatures(signatures)
+	if err != nil {
+		t.Fatalf("BlsAggregateSignatures failed %v", err)
+	}
+	aggregatePublicKey, err := BlsAggregatePublicKeys(publicKeys)
+	if err != nil {
+		t.Fatalf("BlsAggregatePublicKeys failed %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		publicKey   string
+		signature   string
+		message     []byte
+		expectedErr error
+	}{
+		{"single", publicKeys[0], signatures[0], message, nil},
+		{"single with 0x prefix", "0x" + publicKeys[1], "0x" + signatures[1], message, nil},
+		{"other key", publicKeys[1], signatures[0], message, ErrBlsSignatureInvalid},
+		{"other message", publicKeys[0], signatures[0], []byte("foo"), ErrBlsSignatureInvalid},
+		{"aggregate", aggregatePublicKey, aggregateSignature, message, nil},
+		{"aggregate with one key", publicKeys[0], aggregateSignature, message, ErrBlsSignatureInvalid},
+	}
+	for _, test := range tests {
+		if err := BlsVerify(test.publicKey, test.signature, test.message, false); !errors.Is(err, test.expectedErr) {
+			t.Errorf("BlsVerify(%s) returned %v, expected %v", test.name, err, test.expectedErr)
+		}
+	}
+
+	if _, err := BlsAggregateSignatures(nil); !errors.Is(err, ErrBlsNothingToCombine) {
+		t.Errorf("BlsAggregateSignatures(nil) returned %v, expected %v", err, ErrBlsNothingToCombine)
+	}
+	if _, err := BlsMessageHash([]byte("short"), true); err == nil {
+		t.Errorf("BlsMessageHash of a short hash succeeded, expected an error")
+	}
+}
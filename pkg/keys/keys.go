@@ -2,51 +2,41 @@ package keys
 
 import (
 	"fmt"
-	"os"
-	"path"
 	"strings"
 
 	ethCommon "github.com/ethereum/go-ethereum/common"
-	"github.com/intelchain-itc/intelchain/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/intelchain-itc/itc-sdk/pkg/address"
-
-	// "github.com/ethereum/go-ethereum/crypto"
-
-	homedir "github.com/mitchellh/go-homedir"
+	"github.com/intelchain-itc/itc-sdk/pkg/store"
 )
 
-func checkAndMakeKeyDirIfNeeded() string {
-	userDir, _ := homedir.Dir()
-	itcCLIDir := path.Join(userDir, ".itc_cli", "keystore")
-	if _, err := os.Stat(itcCLIDir); os.IsNotExist(err) {
-		// Double check with Leo what is right file persmission
-		os.Mkdir(itcCLIDir, 0700)
-	}
-
-	return itcCLIDir
-}
-
-func ListKeys(keystoreDir string) {
-	itcCLIDir := checkAndMakeKeyDirIfNeeded()
-	scryptN := keystore.StandardScryptN
-	scryptP := keystore.StandardScryptP
-	ks := keystore.NewKeyStore(itcCLIDir, scryptN, scryptP)
-	// keystore.KeyStore
-	allAccounts := ks.Accounts()
+// ListKeys prints the keys of the local accounts of the selected keystore
+// backend
+func ListKeys() {
 	fmt.Printf("Intelchain Address:%s File URL:\n", strings.Repeat(" ", ethCommon.AddressLength*2))
-	for _, account := range allAccounts {
-		fmt.Printf("%s\t\t %s\n", address.ToBech32(account.Address), account.URL)
+	for _, name := range store.LocalAccounts() {
+		ks := store.FromAccountName(name)
+		if ks == nil {
+			continue
+		}
+		for _, account := range ks.Accounts() {
+			fmt.Printf("%s\t\t %s\n", address.ToBech32(account.Address), account.URL)
+		}
 	}
 }
 
-func AddNewKey(password string) {
-	itcCLIDir := checkAndMakeKeyDirIfNeeded()
-	scryptN := keystore.StandardScryptN
-	scryptP := keystore.StandardScryptP
-	ks := keystore.NewKeyStore(itcCLIDir, scryptN, scryptP)
-	account, err := ks.NewAccount(password)
+// AddNewKey creates a key in the named local account of the selected
+// keystore backend, which fails when the backend is read-only
+func AddNewKey(name, password string) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		fmt.Printf("new account error: %v\n", err)
+		return
+	}
+	account, err := store.ImportECDSA(name, key, password)
 	if err != nil {
 		fmt.Printf("new account error: %v\n", err)
+		return
 	}
 	fmt.Printf("account: %s\n", address.ToBech32(account.Address))
 	fmt.Printf("URL: %s\n", account.URL)
@@ -1,24 +1,76 @@
 package keys
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 
 	secp256k1 "github.com/btcsuite/btcd/btcec"
 	"github.com/cosmos/cosmos-sdk/crypto/keys/hd"
-	"github.com/tyler-smith/go-bip39"
+
+	"github.com/intelchain-itc/itc-sdk/pkg/mnemonic"
+)
+
+const (
+	// IntelchainCoinType is the registered BIP44 coin type of intelchain
+	IntelchainCoinType = 1023
+	// EthereumCoinType is the BIP44 coin type used by MetaMask and most
+	// ethereum wallets
+	EthereumCoinType = 60
 )
 
+var (
+	ErrInvalidHDPath = errors.New("invalid BIP32 derivation path")
+)
+
+// HDPath returns the BIP44 path m/44'/coinType'/account'/0/index
+func HDPath(coinType, account, index uint32) string {
+	return fmt.Sprintf("m/44'/%d'/%d'/0/%d", coinType, account, index)
+}
+
+// normalizeHDPath checks every component of a BIP32 path and strips its
+// leading m/, the form the derivation expects
+func normalizeHDPath(path string) (string, error) {
+	trimmed := strings.TrimPrefix(strings.TrimSpace(path), "m/")
+	if trimmed == "" {
+		return "", fmt.Errorf("%w: %q has no components", ErrInvalidHDPath, path)
+	}
+	for _, part := range strings.Split(trimmed, "/") {
+		number := strings.TrimSuffix(part, "'")
+		if _, err := strconv.ParseUint(number, 10, 31); err != nil {
+			return "", fmt.Errorf("%w: %q has a bad component %q", ErrInvalidHDPath, path, part)
+		}
+	}
+	return trimmed, nil
+}
+
 // FromMnemonicSeedAndPassphrase mimics the Intelchain JS sdk in deriving the
 // private, public key pair from the mnemonic, its index, and empty string password.
 // Note that an index k would be the k-th key generated using the same mnemonic.
 func FromMnemonicSeedAndPassphrase(mnemonic string, index int) (*secp256k1.PrivateKey, *secp256k1.PublicKey) {
-	seed := bip39.NewSeed(mnemonic, "")
-	master, ch := hd.ComputeMastersFromSeed(seed)
-	private, _ := hd.DerivePrivateKeyForPath(
-		master,
-		ch,
-		fmt.Sprintf("44'/1023'/0'/0/%d", index),
-	)
-
-	return secp256k1.PrivKeyFromBytes(secp256k1.S256(), private[:])
+	return FromMnemonicAccountAndIndex(mnemonic, 0, uint32(index))
+}
+
+// FromMnemonicAccountAndIndex derives the private, public key pair at the
+// BIP44 path 44'/1023'/account'/0/index of the mnemonic
+func FromMnemonicAccountAndIndex(m string, account, index uint32) (*secp256k1.PrivateKey, *secp256k1.PublicKey) {
+	private, public, _ := FromMnemonicPath(m, "", HDPath(IntelchainCoinType, account, index))
+	return private, public
+}
+
+// FromMnemonicPath derives the private, public key pair at an arbitrary BIP32
+// path from the mnemonic and its optional bip39 passphrase
+func FromMnemonicPath(m, passphrase, path string) (*secp256k1.PrivateKey, *secp256k1.PublicKey, error) {
+	derivationPath, err := normalizeHDPath(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	master, ch := hd.ComputeMastersFromSeed(mnemonic.Seed(m, passphrase))
+	private, err := hd.DerivePrivateKeyForPath(master, ch, derivationPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	sk, pk := secp256k1.PrivKeyFromBytes(secp256k1.S256(), private[:])
+	return sk, pk, nil
 }
@@ -1,7 +1,10 @@
 package keys
 
 import (
+	"errors"
 	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
 )
 
 const (
@@ -24,3 +27,42 @@ func TestMnemonic(t *testing.T) {
 		t.Errorf("Public Compressed key mismatch %s != %s", pkCompressed, publicKey)
 	}
 }
+
+func TestMnemonicPath(t *testing.T) {
+	tests := []struct {
+		mnemonic        string
+		passphrase      string
+		path            string
+		expectedAddress string
+		expectedErr     error
+	}{
+		{
+			"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", "",
+			HDPath(EthereumCoinType, 0, 0), "0x9858EfFD232B4033E47d90003D41EC34EcaEda94", nil,
+		},
+		{phrase, "", "m/44'/1023'/0'/0/0", "", nil},
+		{phrase, "", "44'/1023'/0'/0/0", "", nil},
+		{phrase, "", "m/", "", ErrInvalidHDPath},
+		{phrase, "", "m/44'//0", "", ErrInvalidHDPath},
+		{phrase, "", "m/44'/x/0", "", ErrInvalidHDPath},
+		{phrase, "", "m/44'/2147483648/0", "", ErrInvalidHDPath},
+	}
+
+	for _, test := range tests {
+		private, _, err := FromMnemonicPath(test.mnemonic, test.passphrase, test.path)
+		if !errors.Is(err, test.expectedErr) {
+			t.Errorf("FromMnemonicPath(%s) returned error %v, expected %v", test.path, err, test.expectedErr)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if test.expectedAddress != "" {
+			if addr := crypto.PubkeyToAddress(private.ToECDSA().PublicKey).Hex(); addr != test.expectedAddress {
+				t.Errorf("FromMnemonicPath(%s) returned %s, expected %s", test.path, addr, test.expectedAddress)
+			}
+		} else if sk := EncodeHex(private, private.PubKey()).PrivateKey; sk != privateKey {
+			t.Errorf("FromMnemonicPath(%s) returned %s, expected %s", test.path, sk, privateKey)
+		}
+	}
+}
@@ -1,17 +1,115 @@
 package mnemonic
 
 import (
+	"crypto/sha256"
 	"errors"
+	"fmt"
+	"math/big"
+	"strings"
 
 	"github.com/tyler-smith/go-bip39"
+	"github.com/tyler-smith/go-bip39/wordlists"
+	"golang.org/x/text/unicode/norm"
 )
 
 var (
 	InvalidMnemonic = errors.New("invalid mnemonic given")
+
+	// languages are tried in this order, the chinese wordlists share words
+	languages = []string{
+		"english", "chinese_simplified", "chinese_traditional", "french",
+		"italian", "japanese", "korean", "spanish",
+	}
+	wordIndices = map[string]map[string]int{}
 )
 
+func init() {
+	for language, list := range map[string][]string{
+		"english":             wordlists.English,
+		"chinese_simplified":  wordlists.ChineseSimplified,
+		"chinese_traditional": wordlists.ChineseTraditional,
+		"french":              wordlists.French,
+		"italian":             wordlists.Italian,
+		"japanese":            wordlists.Japanese,
+		"korean":              wordlists.Korean,
+		"spanish":             wordlists.Spanish,
+	} {
+		indices := make(map[string]int, len(list))
+		for i, word := range list {
+			indices[norm.NFKD.String(word)] = i
+		}
+		wordIndices[language] = indices
+	}
+}
+
 func Generate() string {
 	entropy, _ := bip39.NewEntropy(256)
 	mnemonic, _ := bip39.NewMnemonic(entropy)
 	return mnemonic
 }
+
+// Normalize is the mnemonic in NFKD with its words separated by single spaces
+func Normalize(mnemonic string) string {
+	return strings.Join(strings.Fields(norm.NFKD.String(mnemonic)), " ")
+}
+
+// Validate checks the words of the mnemonic against every bip39 wordlist
+// and verifies its checksum, returning the language of the mnemonic
+func Validate(mnemonic string) (string, error) {
+	words := strings.Fields(Normalize(mnemonic))
+	if n := len(words); n%3 != 0 || n < 12 || n > 24 {
+		return "", fmt.Errorf("%w: expected 12, 15, 18, 21 or 24 words, got %d", InvalidMnemonic, n)
+	}
+	checksumMismatch := ""
+	for _, language := range languages {
+		indices, ok := lookupWords(words, wordIndices[language])
+		if !ok {
+			continue
+		}
+		if checksumMatches(indices) {
+			return language, nil
+		}
+		if checksumMismatch == "" {
+			checksumMismatch = language
+		}
+	}
+	if checksumMismatch != "" {
+		return "", fmt.Errorf("%w: checksum of %s mnemonic does not match", InvalidMnemonic, checksumMismatch)
+	}
+	return "", fmt.Errorf("%w: words are not from a single bip39 wordlist", InvalidMnemonic)
+}
+
+// Seed returns the bip39 seed of the mnemonic and its optional passphrase,
+// both normalized as the specification requires
+func Seed(mnemonic, passphrase string) []byte {
+	return bip39.NewSeed(Normalize(mnemonic), norm.NFKD.String(passphrase))
+}
+
+func lookupWords(words []string, list map[string]int) ([]int, bool) {
+	indices := make([]int, len(words))
+	for i, word := range words {
+		index, ok := list[word]
+		if !ok {
+			return nil, false
+		}
+		indices[i] = index
+	}
+	return indices, true
+}
+
+// checksumMatches splits the 11 bit word indices into the entropy and its
+// checksum, which is the leading entropy/32 bits of its sha256 hash
+func checksumMatches(indices []int) bool {
+	b := new(big.Int)
+	for _, index := range indices {
+		b.Lsh(b, 11)
+		b.Or(b, big.NewInt(int64(index)))
+	}
+	entropyBits := len(indices) * 11 * 32 / 33
+	checksumBits := uint(entropyBits / 32)
+	checksum := new(big.Int).And(b, big.NewInt(1<<checksumBits-1))
+	entropy := make([]byte, entropyBits/8)
+	new(big.Int).Rsh(b, checksumBits).FillBytes(entropy)
+	hash := sha256.Sum256(entropy)
+	return uint64(hash[0]>>(8-checksumBits)) == checksum.Uint64()
+}
@@ -0,0 +1,63 @@
+package mnemonic
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		mnemonic         string
+		expectedLanguage string
+		expectedValid    bool
+	}{
+		{"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", "english", true},
+		{"  abandon abandon abandon abandon abandon abandon\tabandon abandon abandon abandon abandon about ", "english", true},
+		{strings.Repeat("zoo ", 23) + "vote", "english", true},
+		{"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon", "", false},
+		{"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", "", false},
+		{"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon foobar", "", false},
+		{"ábaco ábaco ábaco ábaco ábaco ábaco ábaco ábaco ábaco ábaco ábaco abierto", "spanish", true},
+		{"あいこくしん あいこくしん あいこくしん あいこくしん あいこくしん あいこくしん あいこくしん あいこくしん あいこくしん あいこくしん あいこくしん あおぞら", "japanese", true},
+	}
+
+	for _, test := range tests {
+		language, err := Validate(test.mnemonic)
+		if (err == nil) != test.expectedValid {
+			t.Errorf("Validate(%q) returned error %v, expected valid %v", test.mnemonic, err, test.expectedValid)
+		}
+		if err != nil && !errors.Is(err, InvalidMnemonic) {
+			t.Errorf("Validate(%q) returned error %v, expected it to wrap %v", test.mnemonic, err, InvalidMnemonic)
+		}
+		if language != test.expectedLanguage {
+			t.Errorf("Validate(%q) returned %s, expected %s", test.mnemonic, language, test.expectedLanguage)
+		}
+	}
+}
+
+func TestSeed(t *testing.T) {
+	tests := []struct {
+		mnemonic   string
+		passphrase string
+		expected   string
+	}{
+		{
+			"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+			"TREZOR",
+			"c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+		},
+		{
+			" abandon  abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon\tabout\n",
+			"TREZOR",
+			"c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+		},
+	}
+
+	for _, test := range tests {
+		if seed := hex.EncodeToString(Seed(test.mnemonic, test.passphrase)); seed != test.expected {
+			t.Errorf("Seed(%q, %q) returned %s, expected %s", test.mnemonic, test.passphrase, seed, test.expected)
+		}
+	}
+}
@@ -0,0 +1,305 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/intelchain-itc/itc-sdk/pkg/common"
+	"gopkg.in/yaml.v2"
+)
+
+// The output formats of query results
+const (
+	JSON  = "json"
+	YAML  = "yaml"
+	Table = "table"
+	CSV   = "csv"
+)
+
+// Formats lists the output formats
+var Formats = []string{JSON, YAML, Table, CSV}
+
+// Column is a field of a row: Path is the dot separated path of the field
+// within the row, empty for the row itself, and Amount converts atto to ITC
+type Column struct {
+	Header string
+	Path   string
+	Amount bool
+}
+
+// View is how a result reads as rows. Rows is the dot separated path of the
+// list of rows within the result, where * stands for every entry of an
+// object and the entry's key is set as field Key of its rows. Columns picks
+// the fields of each row; without columns every field is flattened into one.
+type View struct {
+	Rows    string
+	Key     string
+	Columns []Column
+}
+
+// Valid reports whether format is an output format
+func Valid(format string) bool {
+	for _, f := range Formats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// Decode decodes a JSON result keeping numbers exact
+func Decode(raw []byte) (interface{}, error) {
+	var v interface{}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Write writes the JSON result raw to w in format, laid out by view for
+// tables and CSV
+func Write(w io.Writer, format string, raw []byte, view View) error {
+	v, err := Decode(raw)
+	if err != nil {
+		return err
+	}
+	switch format {
+	case JSON:
+		out, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(out))
+		return err
+	case YAML:
+		out, err := yaml.Marshal(plain(v))
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(out)
+		return err
+	case Table, CSV:
+		header, records := Rows(v, view)
+		if format == CSV {
+			c := csv.NewWriter(w)
+			c.Write(header)
+			c.WriteAll(records)
+			return c.Error()
+		}
+		t := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(t, strings.ToUpper(strings.Join(header, "\t")))
+		for _, record := range records {
+			fmt.Fprintln(t, strings.Join(record, "\t"))
+		}
+		return t.Flush()
+	default:
+		return fmt.Errorf("unknown output format %s, expected one of %s", format, strings.Join(Formats, ", "))
+	}
+}
+
+// Rows lays the decoded result v out as a header and records
+func Rows(v interface{}, view View) ([]string, [][]string) {
+	rows := []interface{}{}
+	if view.Rows == "" {
+		if list, ok := v.([]interface{}); ok {
+			rows = list
+		} else {
+			rows = append(rows, v)
+		}
+	} else {
+		rows = collect(v, strings.Split(view.Rows, "."), view.Key)
+	}
+
+	columns := view.Columns
+	if len(columns) == 0 {
+		seen := map[string]bool{}
+		paths := []string{}
+		for _, row := range rows {
+			for path := range Flatten(row) {
+				if !seen[path] {
+					seen[path] = true
+					paths = append(paths, path)
+				}
+			}
+		}
+		sort.Strings(paths)
+		for _, path := range paths {
+			columns = append(columns, Column{Header: path, Path: path})
+		}
+	}
+
+	header := make([]string, len(columns))
+	for i, c := range columns {
+		header[i] = c.Header
+	}
+	records := make([][]string, len(rows))
+	for i, row := range rows {
+		flat := Flatten(row)
+		records[i] = make([]string, len(columns))
+		for j, c := range columns {
+			value, ok := flat[c.Path]
+			if !ok {
+				value = cell(lookup(row, c.Path))
+			}
+			if c.Amount {
+				value = ToITC(value)
+			}
+			records[i][j] = value
+		}
+	}
+	return header, records
+}
+
+// collect gathers the rows at path within v
+func collect(v interface{}, path []string, key string) []interface{} {
+	if len(path) == 0 {
+		if list, ok := v.([]interface{}); ok {
+			return list
+		}
+		return []interface{}{v}
+	}
+	object, ok := v.(map[string]interface{})
+	if !ok {
+		return []interface{}{}
+	}
+	if path[0] != "*" {
+		return collect(object[path[0]], path[1:], key)
+	}
+	keys := []string{}
+	for k := range object {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	rows := []interface{}{}
+	for _, k := range keys {
+		for _, row := range collect(object[k], path[1:], key) {
+			if fields, ok := row.(map[string]interface{}); ok && key != "" {
+				keyed := map[string]interface{}{key: k}
+				for field, value := range fields {
+					keyed[field] = value
+				}
+				row = keyed
+			}
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+func lookup(v interface{}, path string) interface{} {
+	if path == "" {
+		return v
+	}
+	for _, field := range strings.Split(path, ".") {
+		object, ok := v.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		v = object[field]
+	}
+	return v
+}
+
+// Flatten turns nested objects into dot separated paths, entries of lists
+// of objects are numbered and lists of values joined by spaces
+func Flatten(v interface{}) map[string]string {
+	flat := map[string]string{}
+	flatten("", v, flat)
+	return flat
+}
+
+func flatten(prefix string, v interface{}, flat map[string]string) {
+	join := func(field string) string {
+		if prefix == "" {
+			return field
+		}
+		return prefix + "." + field
+	}
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for field, inner := range value {
+			flatten(join(field), inner, flat)
+		}
+	case []interface{}:
+		values := []string{}
+		for i, inner := range value {
+			switch inner.(type) {
+			case map[string]interface{}, []interface{}:
+				flatten(join(fmt.Sprint(i)), inner, flat)
+			default:
+				values = append(values, cell(inner))
+			}
+		}
+		if len(values) > 0 || len(value) == 0 {
+			flat[prefix] = strings.Join(values, " ")
+		}
+	default:
+		flat[prefix] = cell(value)
+	}
+}
+
+func cell(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case map[string]interface{}, []interface{}:
+		out, _ := json.Marshal(value)
+		return string(out)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// ToITC converts an amount in atto, a decimal or hex number, to ITC. Values
+// that are not whole numbers are returned as they are.
+func ToITC(amount string) string {
+	atto, ok := new(big.Rat).SetString(amount)
+	if strings.HasPrefix(amount, "0x") {
+		var n *big.Int
+		if n, ok = new(big.Int).SetString(amount, 0); ok {
+			atto = new(big.Rat).SetInt(n)
+		}
+	}
+	if !ok || !atto.IsInt() {
+		return amount
+	}
+	return common.FormatITC(atto.Num())
+}
+
+// plain replaces the exact JSON numbers of v by integers or floats so they
+// marshal as YAML numbers
+func plain(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for field, inner := range value {
+			value[field] = plain(inner)
+		}
+	case []interface{}:
+		for i, inner := range value {
+			value[i] = plain(inner)
+		}
+	case json.Number:
+		if n, err := value.Int64(); err == nil {
+			return n
+		}
+		if n, ok := new(big.Int).SetString(value.String(), 10); ok {
+			// beyond int64, keep every digit
+			return n.String()
+		}
+		if f, err := value.Float64(); err == nil {
+			return f
+		}
+	}
+	return v
+}
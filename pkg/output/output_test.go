@@ -0,0 +1,85 @@
+package output
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestToITC(t *testing.T) {
+	tests := []struct {
+		amount   string
+		expected string
+	}{
+		{"1000000000000000000", "1"},
+		{"2500000000000000000000", "2500"},
+		{"0x0de0b6b3a7640000", "1"},
+		{"1500000000000000000.000000000000000000", "1.5"},
+		{"1", "0.000000000000000001"},
+		{"0", "0"},
+		{"1.5", "1.5"},
+		{"active", "active"},
+	}
+	for _, test := range tests {
+		if result := ToITC(test.amount); result != test.expected {
+			t.Errorf("ToITC(%s) returned %s, expected %s", test.amount, result, test.expected)
+		}
+	}
+}
+
+func TestRows(t *testing.T) {
+	committees := `{"current": {"quorum-deciders": {
+		"shard-1": {"committee-members": [{"bls-public-key": "bb", "effective-stake": "3000000000000000000"}]},
+		"shard-0": {"committee-members": [{"bls-public-key": "aa", "effective-stake": "12345678901234567890123"}]}
+	}}}`
+	v, err := Decode([]byte(committees))
+	if err != nil {
+		t.Fatal(err)
+	}
+	header, records := Rows(v, View{
+		Rows: "current.quorum-deciders.*.committee-members",
+		Key:  "shard",
+		Columns: []Column{
+			{"shard", "shard", false}, {"bls-key", "bls-public-key", false}, {"effective-stake", "effective-stake", true},
+		},
+	})
+	expectedRecords := [][]string{{"shard-0", "aa", "12345.678901234567890123"}, {"shard-1", "bb", "3"}}
+	if !reflect.DeepEqual(header, []string{"shard", "bls-key", "effective-stake"}) || !reflect.DeepEqual(records, expectedRecords) {
+		t.Errorf("Rows returned %v %v, expected %v", header, records, expectedRecords)
+	}
+
+	delegations, _ := Decode([]byte(`[{"validator_address": "itc1a", "amount": 5, "Undelegations": [{"Amount": 1, "Epoch": 7}], "keys": ["k1", "k2"]}]`))
+	header, records = Rows(delegations, View{})
+	expectedHeader := []string{"Undelegations.0.Amount", "Undelegations.0.Epoch", "amount", "keys", "validator_address"}
+	if !reflect.DeepEqual(header, expectedHeader) || !reflect.DeepEqual(records, [][]string{{"1", "7", "5", "k1 k2", "itc1a"}}) {
+		t.Errorf("Rows returned %v %v, expected the flattened fields", header, records)
+	}
+
+	addresses, _ := Decode([]byte(`["itc1a", "itc1b"]`))
+	if _, records = Rows(addresses, View{Columns: []Column{{"address", "", false}}}); !reflect.DeepEqual(records, [][]string{{"itc1a"}, {"itc1b"}}) {
+		t.Errorf("Rows of a list of addresses returned %v", records)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	raw := []byte(`[{"shard": 0, "amount": 12345678901234567890123}]`)
+	view := View{Columns: []Column{{"shard", "shard", false}, {"amount", "amount", true}}}
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{CSV, "shard,amount\n0,12345.678901234567890123\n"},
+		{Table, "SHARD  AMOUNT\n0      12345.678901234567890123\n"},
+		{YAML, "- amount: \"12345678901234567890123\"\n  shard: 0\n"},
+	}
+	for _, test := range tests {
+		var b bytes.Buffer
+		if err := Write(&b, test.format, raw, view); err != nil || b.String() != test.expected {
+			t.Errorf("Write(%s) wrote %q, %v, expected %q", test.format, b.String(), err, test.expected)
+		}
+	}
+	if err := Write(&bytes.Buffer{}, "xml", raw, view); err == nil || !strings.Contains(err.Error(), "xml") {
+		t.Errorf("Write(xml) returned %v, expected an unknown format error", err)
+	}
+}
@@ -51,6 +51,7 @@ type RpcEnumList struct {
 	GetMedianRawStakeSnapshot               RpcMethod
 	GetCurrentStakingErrorSink              RpcMethod
 	GetTransactionsHistory                  RpcMethod
+	GetStakingTransactionsHistory           RpcMethod
 	GetPendingTxnsInPool                    RpcMethod
 	GetPendingCrosslinks                    RpcMethod
 	GetPendingCXReceipts                    RpcMethod
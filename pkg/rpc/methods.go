@@ -62,6 +62,7 @@ type RpcEnumList struct {
 	GetMedianRawStakeSnapshot               rpcCommon.RpcMethod
 	GetCurrentStakingErrorSink              rpcCommon.RpcMethod
 	GetTransactionsHistory                  rpcCommon.RpcMethod
+	GetStakingTransactionsHistory           rpcCommon.RpcMethod
 	GetPendingTxnsInPool                    rpcCommon.RpcMethod
 	GetPendingCrosslinks                    rpcCommon.RpcMethod
 	GetPendingCXReceipts                    rpcCommon.RpcMethod
@@ -141,6 +142,13 @@ const (
 	catchAllError            = "Catch all RPC error"
 )
 
+// ErrorWithData is an RPC error that came with a data payload, for example
+// the ABI encoded revert reason of a failed call
+type ErrorWithData struct {
+	error
+	Data interface{}
+}
+
 // ErrorCodeToError lifts an untyped error code from RPC to Error value
 func ErrorCodeToError(message string, code float64) error {
 	return errors.Wrap(errors.New(message), codeToMessage(code))
@@ -23,6 +23,10 @@ func baseRequest(method string, node string, params interface{}) ([]byte, error)
 		"method":  method,
 		"params":  params,
 	})
+	return postRequest(node, requestBody)
+}
+
+func postRequest(node string, requestBody []byte) ([]byte, error) {
 	const contentType = "application/json"
 	req := fasthttp.AcquireRequest()
 	req.SetBody(requestBody)
@@ -63,17 +67,95 @@ func Request(method string, node string, params interface{}) (Reply, error) {
 		return nil, err
 	}
 	json.Unmarshal(rawReply, &rpcJSON)
-	if oops := rpcJSON["error"]; oops != nil {
-		errNo := oops.(map[string]interface{})["code"].(float64)
-		errMessage := ""
-		if oops.(map[string]interface{})["message"] != nil {
-			errMessage = oops.(map[string]interface{})["message"].(string)
-		}
-		return nil, ErrorCodeToError(errMessage, errNo)
+	if err := replyError(rpcJSON["error"]); err != nil {
+		return nil, err
 	}
 	return rpcJSON, nil
 }
 
+func replyError(oops interface{}) error {
+	if oops == nil {
+		return nil
+	}
+	errNo := oops.(map[string]interface{})["code"].(float64)
+	errMessage := ""
+	if oops.(map[string]interface{})["message"] != nil {
+		errMessage = oops.(map[string]interface{})["message"].(string)
+	}
+	if data := oops.(map[string]interface{})["data"]; data != nil {
+		return &ErrorWithData{ErrorCodeToError(errMessage, errNo), data}
+	}
+	return ErrorCodeToError(errMessage, errNo)
+}
+
+// rawReply keeps the result undecoded, so big numbers keep their precision
+type rawReply struct {
+	ID     string          `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  interface{}     `json:"error"`
+}
+
+// RequestResult sends the request and decodes its result into v, unlike
+// Request numbers are not rounded through float64
+func RequestResult(method string, node string, params interface{}, v interface{}) error {
+	raw, err := baseRequest(method, node, params)
+	if err != nil {
+		return err
+	}
+	reply := rawReply{}
+	if err := json.Unmarshal(raw, &reply); err != nil {
+		return fmt.Errorf("could not decode reply: %w", err)
+	}
+	if err := replyError(reply.Error); err != nil {
+		return err
+	}
+	return json.Unmarshal(reply.Result, v)
+}
+
+// Call is one request of a batch
+type Call struct {
+	Method string
+	Params interface{}
+}
+
+// BatchRequest sends the calls to node as a single JSON-RPC batch. Results
+// and errors are in the order of the calls, a call failing on the node has
+// an error in place of its result.
+func BatchRequest(node string, calls []Call) ([]json.RawMessage, []error, error) {
+	batch := make([]map[string]interface{}, len(calls))
+	for i, call := range calls {
+		batch[i] = map[string]interface{}{
+			"jsonrpc": common.JSONRPCVersion,
+			"id":      strconv.Itoa(i),
+			"method":  call.Method,
+			"params":  call.Params,
+		}
+	}
+	requestBody, _ := json.Marshal(batch)
+	raw, err := postRequest(node, requestBody)
+	if err != nil {
+		return nil, nil, err
+	}
+	replies := []rawReply{}
+	if err := json.Unmarshal(raw, &replies); err != nil {
+		return nil, nil, fmt.Errorf("could not decode batch reply: %w", err)
+	}
+	results, errs := make([]json.RawMessage, len(calls)), make([]error, len(calls))
+	for i := range errs {
+		errs[i] = fmt.Errorf("no reply to %s in the batch", calls[i].Method)
+	}
+	for _, reply := range replies {
+		i, err := strconv.Atoi(reply.ID)
+		if err != nil || i < 0 || i >= len(calls) {
+			continue
+		}
+		if errs[i] = replyError(reply.Error); errs[i] == nil {
+			results[i] = reply.Result
+		}
+	}
+	return results, errs, nil
+}
+
 // RawRequest is to sidestep the lifting done by Request
 func RawRequest(method string, node string, params interface{}) ([]byte, error) {
 	return baseRequest(method, node, params)
@@ -1,10 +1,57 @@
 package rpc
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 )
 
 func TestRPCRequest(t *testing.T) {
 	fmt.Println("hell rpc?")
 }
+
+func TestBatchRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls := []map[string]interface{}{}
+		json.NewDecoder(r.Body).Decode(&calls)
+		replies := []map[string]interface{}{}
+		for i := len(calls) - 1; i >= 0; i-- {
+			reply := map[string]interface{}{"jsonrpc": "2.0", "id": calls[i]["id"]}
+			if calls[i]["method"] == "itc_broken" {
+				reply["error"] = map[string]interface{}{"code": -32601, "message": "method not found"}
+			} else {
+				reply["result"] = calls[i]["params"]
+			}
+			replies = append(replies, reply)
+		}
+		json.NewEncoder(w).Encode(replies)
+	}))
+	defer server.Close()
+
+	results, errs, err := BatchRequest(server.URL, []Call{
+		{"itc_getBalance", []interface{}{"a"}},
+		{"itc_broken", []interface{}{}},
+		{"itc_getBalance", []interface{}{"b"}},
+	})
+	if err != nil {
+		t.Fatalf("BatchRequest failed %v", err)
+	}
+	for i, expected := range []string{"a", "", "b"} {
+		if expected == "" {
+			if errs[i] == nil || results[i] != nil {
+				t.Errorf("BatchRequest call %d returned %s %v, expected an error", i, results[i], errs[i])
+			}
+			continue
+		}
+		if errs[i] != nil {
+			t.Errorf("BatchRequest call %d failed %v", i, errs[i])
+			continue
+		}
+		params := []string{}
+		if err := json.Unmarshal(results[i], &params); err != nil || params[0] != expected {
+			t.Errorf("BatchRequest call %d returned %s, expected %s", i, results[i], expected)
+		}
+	}
+}
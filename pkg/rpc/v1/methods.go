@@ -60,6 +60,7 @@ var Method = rpcCommon.RpcEnumList{
 	GetMedianRawStakeSnapshot:               fmt.Sprintf("%s_getMedianRawStakeSnapshot", prefix),
 	GetCurrentStakingErrorSink:              fmt.Sprintf("%s_getCurrentStakingErrorSink", prefix),
 	GetTransactionsHistory:                  fmt.Sprintf("%s_getTransactionsHistory", prefix),
+	GetStakingTransactionsHistory:           fmt.Sprintf("%s_getStakingTransactionsHistory", prefix),
 	GetPendingTxnsInPool:                    fmt.Sprintf("%s_pendingTransactions", prefix),
 	GetPendingCrosslinks:                    fmt.Sprintf("%s_getPendingCrossLinks", prefix),
 	GetPendingCXReceipts:                    fmt.Sprintf("%s_getPendingCXReceipts", prefix),
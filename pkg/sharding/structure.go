@@ -21,6 +21,7 @@ var (
 		"ws://127.0.0.1:9800":   "wss://testnet.s0.intelchain.network/ws",
 		"ws://127.0.0.1:9802":   "wss://testnet.s1.intelchain.network/ws",
 	}
+	shardEndpoints = map[int]string{}
 )
 
 func rewriteEndpoint(endpoint string) string {
@@ -30,6 +31,22 @@ func rewriteEndpoint(endpoint string) string {
 	return endpoint
 }
 
+// SetEndpointRewrites adds rewrites of the endpoints the sharding structure
+// reports, on top of the local to public ones
+func SetEndpointRewrites(rewrites map[string]string) {
+	for from, to := range rewrites {
+		localToPublicEndpoints[from] = to
+	}
+}
+
+// SetShardEndpoints routes the given shards to the given HTTP endpoints
+// whatever the sharding structure reports
+func SetShardEndpoints(endpoints map[int]string) {
+	for shard, endpoint := range endpoints {
+		shardEndpoints[shard] = endpoint
+	}
+}
+
 // RPCRoutes reflects the RPC endpoints of the target network across shards
 type RPCRoutes struct {
 	HTTP    string `json:"http"`
@@ -55,6 +72,9 @@ func Structure(node string) ([]RPCRoutes, error) {
 	for i := range result.Result {
 		result.Result[i].HTTP = rewriteEndpoint(result.Result[i].HTTP)
 		result.Result[i].WS = rewriteEndpoint(result.Result[i].WS)
+		if endpoint, exists := shardEndpoints[result.Result[i].ShardID]; exists {
+			result.Result[i].HTTP = endpoint
+		}
 	}
 
 	return result.Result, nil
@@ -0,0 +1,136 @@
+package store
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/intelchain-itc/intelchain/accounts"
+	"github.com/intelchain-itc/itc-sdk/pkg/common"
+	homedir "github.com/mitchellh/go-homedir"
+)
+
+var (
+	// ErrReadOnly is returned when a read-only backend is asked to change
+	ErrReadOnly = errors.New("keystore is read-only")
+
+	backend Backend = NewDirBackend(DefaultDir())
+)
+
+// Backend keeps named accounts, each one a keystore holding a single key
+type Backend interface {
+	// Location describes where the accounts are kept
+	Location() string
+	// Names lists the names of the accounts
+	Names() []string
+	// Open returns the keystore of the named account
+	Open(name string) (Keystore, error)
+	// ImportECDSA encrypts the key with the passphrase into the named account
+	ImportECDSA(name string, key *ecdsa.PrivateKey, passphrase string) (accounts.Account, error)
+	// ImportKeyFile stores an already encrypted key file in the named account
+	ImportKeyFile(name, fileName string, keyJSON []byte) error
+	// Remove deletes the named account and its key
+	Remove(name string) error
+}
+
+// SetBackend replaces the backend used by the package level functions
+func SetBackend(b Backend) {
+	backend = b
+}
+
+// CurrentBackend returns the backend used by the package level functions
+func CurrentBackend() Backend {
+	return backend
+}
+
+// DefaultDir is the directory accounts are kept in unless configured otherwise
+func DefaultDir() string {
+	uDir, _ := homedir.Dir()
+	return filepath.Join(uDir, common.DefaultConfigDirName, common.DefaultConfigAccountAliasesDirName)
+}
+
+// DirBackend keeps every account in its own sub directory of a directory,
+// which is only created once the first account is written
+type DirBackend struct {
+	dir string
+}
+
+// NewDirBackend returns a backend keeping its accounts under dir
+func NewDirBackend(dir string) *DirBackend {
+	return &DirBackend{dir}
+}
+
+func (b *DirBackend) Location() string {
+	return b.dir
+}
+
+func (b *DirBackend) Names() []string {
+	files, _ := ioutil.ReadDir(b.dir)
+	names := []string{}
+	for _, node := range files {
+		if node.IsDir() {
+			names = append(names, filepath.Base(node.Name()))
+		}
+	}
+	return names
+}
+
+func (b *DirBackend) Open(name string) (Keystore, error) {
+	return common.KeyStoreForPath(filepath.Join(b.dir, name)), nil
+}
+
+func (b *DirBackend) ImportECDSA(
+	name string, key *ecdsa.PrivateKey, passphrase string,
+) (accounts.Account, error) {
+	ks, _ := b.Open(name)
+	return ks.ImportECDSA(key, passphrase)
+}
+
+func (b *DirBackend) ImportKeyFile(name, fileName string, keyJSON []byte) error {
+	accountDir := filepath.Join(b.dir, name)
+	if err := os.MkdirAll(accountDir, 0700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(accountDir, filepath.Base(fileName)), keyJSON, 0600)
+}
+
+func (b *DirBackend) Remove(name string) error {
+	if name == "" || filepath.Base(name) != name {
+		return fmt.Errorf("invalid account name %q", name)
+	}
+	return os.RemoveAll(filepath.Join(b.dir, name))
+}
+
+type readOnlyBackend struct {
+	Backend
+}
+
+// ReadOnly wraps a backend so accounts can be listed and used for signing,
+// but never created, imported or removed
+func ReadOnly(b Backend) Backend {
+	return readOnlyBackend{b}
+}
+
+func (b readOnlyBackend) Open(name string) (Keystore, error) {
+	for _, n := range b.Names() {
+		if n == name {
+			return b.Backend.Open(name)
+		}
+	}
+	return nil, fmt.Errorf("no account named %s", name)
+}
+
+func (readOnlyBackend) ImportECDSA(string, *ecdsa.PrivateKey, string) (accounts.Account, error) {
+	return accounts.Account{}, ErrReadOnly
+}
+
+func (readOnlyBackend) ImportKeyFile(string, string, []byte) error {
+	return ErrReadOnly
+}
+
+func (readOnlyBackend) Remove(string) error {
+	return ErrReadOnly
+}
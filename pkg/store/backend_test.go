@@ -0,0 +1,43 @@
+package store
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestReadOnlyBackend(t *testing.T) {
+	backend := NewMemoryBackend()
+	key, _ := crypto.GenerateKey()
+	if _, err := backend.ImportECDSA("foo", key, ""); err != nil {
+		t.Fatalf("ImportECDSA failed %v", err)
+	}
+
+	readOnly := ReadOnly(backend)
+	if names := readOnly.Names(); len(names) != 1 || names[0] != "foo" {
+		t.Errorf("Names() returned %v, expected [foo]", names)
+	}
+	if ks, err := readOnly.Open("foo"); err != nil || len(ks.Accounts()) != 1 {
+		t.Errorf("Open(foo) failed %v", err)
+	}
+	if _, err := readOnly.Open("bar"); err == nil || errors.Is(err, ErrReadOnly) {
+		t.Errorf("Open(bar) returned %v, expected an account not found error", err)
+	}
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"ImportECDSA", func() error { _, err := readOnly.ImportECDSA("bar", key, ""); return err }()},
+		{"ImportKeyFile", readOnly.ImportKeyFile("bar", "key.json", []byte("{}"))},
+		{"Remove", readOnly.Remove("foo")},
+	}
+	for _, test := range tests {
+		if !errors.Is(test.err, ErrReadOnly) {
+			t.Errorf("%s returned %v, expected %v", test.name, test.err, ErrReadOnly)
+		}
+	}
+	if names := backend.Names(); len(names) != 1 {
+		t.Errorf("Names() returned %v after read-only changes, expected [foo]", names)
+	}
+}
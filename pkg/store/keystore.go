@@ -0,0 +1,39 @@
+package store
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"time"
+
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/intelchain-itc/intelchain/accounts"
+	"github.com/intelchain-itc/intelchain/accounts/keystore"
+	"github.com/intelchain-itc/intelchain/core/types"
+	staking "github.com/intelchain-itc/intelchain/staking/types"
+)
+
+// Keystore holds the keys of an account and signs with them, whether the
+// keys are files on disk as with *keystore.KeyStore or kept in memory
+type Keystore interface {
+	Accounts() []accounts.Account
+	Find(a accounts.Account) (accounts.Account, error)
+	NewAccount(passphrase string) (accounts.Account, error)
+	ImportECDSA(priv *ecdsa.PrivateKey, passphrase string) (accounts.Account, error)
+	Export(a accounts.Account, passphrase, newPassphrase string) ([]byte, error)
+	GetDecryptedKey(a accounts.Account, auth string) (accounts.Account, *keystore.Key, error)
+	Lock(addr ethCommon.Address) error
+	Unlock(a accounts.Account, passphrase string) error
+	TimedUnlock(a accounts.Account, passphrase string, timeout time.Duration) error
+	SignHash(a accounts.Account, hash []byte) ([]byte, error)
+	SignHashWithPassphrase(a accounts.Account, passphrase string, hash []byte) ([]byte, error)
+	SignTx(a accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+	SignEthTx(a accounts.Account, tx *types.EthTransaction, chainID *big.Int) (*types.EthTransaction, error)
+	SignStakingTx(
+		a accounts.Account, tx *staking.StakingTransaction, chainID *big.Int,
+	) (*staking.StakingTransaction, error)
+}
+
+var (
+	_ Keystore = (*keystore.KeyStore)(nil)
+	_ Keystore = (*MemoryKeystore)(nil)
+)
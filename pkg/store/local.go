@@ -1,45 +1,18 @@
 package store
 
 import (
+	"crypto/ecdsa"
 	"fmt"
-	"io/ioutil"
-	"os"
-	"path"
 	"time"
 
 	"github.com/intelchain-itc/intelchain/accounts"
-	"github.com/intelchain-itc/intelchain/accounts/keystore"
 	"github.com/intelchain-itc/itc-sdk/pkg/address"
-	"github.com/intelchain-itc/itc-sdk/pkg/common"
-	c "github.com/intelchain-itc/itc-sdk/pkg/common"
 	"github.com/pkg/errors"
-
-	homedir "github.com/mitchellh/go-homedir"
 )
 
-func init() {
-	uDir, _ := homedir.Dir()
-	itcCLIDir := path.Join(uDir, common.DefaultConfigDirName, common.DefaultConfigAccountAliasesDirName)
-	if _, err := os.Stat(itcCLIDir); os.IsNotExist(err) {
-		os.MkdirAll(itcCLIDir, 0700)
-	}
-}
-
 // LocalAccounts returns a slice of local account alias names
 func LocalAccounts() []string {
-	uDir, _ := homedir.Dir()
-	files, _ := ioutil.ReadDir(path.Join(
-		uDir,
-		common.DefaultConfigDirName,
-		common.DefaultConfigAccountAliasesDirName,
-	))
-	accounts := []string{}
-	for _, node := range files {
-		if node.IsDir() {
-			accounts = append(accounts, path.Base(node.Name()))
-		}
-	}
-	return accounts
+	return backend.Names()
 }
 
 var (
@@ -73,6 +46,9 @@ func DoesNamedAccountExist(name string) bool {
 // Returns itc address for account name if exists
 func AddressFromAccountName(name string) (string, error) {
 	ks := FromAccountName(name)
+	if ks == nil {
+		return "", fmt.Errorf("Keystore not found.")
+	}
 	// FIXME: Assume 1 account per keystore for now
 	for _, account := range ks.Accounts() {
 		return address.ToBech32(account.Address), nil
@@ -81,7 +57,7 @@ func AddressFromAccountName(name string) (string, error) {
 }
 
 // FromAddress will return nil if the bech32 string is not found in the imported accounts
-func FromAddress(bech32 string) *keystore.KeyStore {
+func FromAddress(bech32 string) Keystore {
 	for _, name := range LocalAccounts() {
 		ks := FromAccountName(name)
 		allAccounts := ks.Accounts()
@@ -94,22 +70,37 @@ func FromAddress(bech32 string) *keystore.KeyStore {
 	return nil
 }
 
-func FromAccountName(name string) *keystore.KeyStore {
-	uDir, _ := homedir.Dir()
-	p := path.Join(uDir, c.DefaultConfigDirName, c.DefaultConfigAccountAliasesDirName, name)
-	return common.KeyStoreForPath(p)
+// FromAccountName returns the keystore of the named account, it is nil when
+// the backend can not open it
+func FromAccountName(name string) Keystore {
+	ks, _ := backend.Open(name)
+	return ks
+}
+
+// ImportECDSA encrypts the key with the passphrase into the named account
+func ImportECDSA(name string, key *ecdsa.PrivateKey, passphrase string) (accounts.Account, error) {
+	return backend.ImportECDSA(name, key, passphrase)
+}
+
+// ImportKeyFile stores an already encrypted key file in the named account
+func ImportKeyFile(name, fileName string, keyJSON []byte) error {
+	return backend.ImportKeyFile(name, fileName, keyJSON)
+}
+
+// RemoveAccount deletes the named account and its key
+func RemoveAccount(name string) error {
+	return backend.Remove(name)
 }
 
 func DefaultLocation() string {
-	uDir, _ := homedir.Dir()
-	return path.Join(uDir, c.DefaultConfigDirName, c.DefaultConfigAccountAliasesDirName)
+	return backend.Location()
 }
 
-func UnlockedKeystore(from, passphrase string) (*keystore.KeyStore, *accounts.Account, error) {
+func UnlockedKeystore(from, passphrase string) (Keystore, *accounts.Account, error) {
 	return UnlockedKeystoreTimeLimit(from, passphrase, 0)
 }
 
-func LockKeystore(from string) (*keystore.KeyStore, *accounts.Account, error) {
+func LockKeystore(from string) (Keystore, *accounts.Account, error) {
 	sender := address.Parse(from)
 	ks := FromAddress(address.ToBech32(sender))
 	if ks == nil {
@@ -125,7 +116,7 @@ func LockKeystore(from string) (*keystore.KeyStore, *accounts.Account, error) {
 	return ks, &account, nil
 }
 
-func UnlockedKeystoreTimeLimit(from, passphrase string, time time.Duration) (*keystore.KeyStore, *accounts.Account, error) {
+func UnlockedKeystoreTimeLimit(from, passphrase string, time time.Duration) (Keystore, *accounts.Account, error) {
 	sender := address.Parse(from)
 	ks := FromAddress(address.ToBech32(sender))
 	if ks == nil {
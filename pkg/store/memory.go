@@ -0,0 +1,291 @@
+package store
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/intelchain-itc/intelchain/accounts"
+	"github.com/intelchain-itc/intelchain/accounts/keystore"
+	"github.com/intelchain-itc/intelchain/core/types"
+	staking "github.com/intelchain-itc/intelchain/staking/types"
+	"github.com/intelchain-itc/itc-sdk/pkg/common"
+	"github.com/pborman/uuid"
+)
+
+var (
+	// ErrNoKey is returned for an account the keystore holds no key of
+	ErrNoKey = errors.New("no key for the given address")
+	// ErrLocked is returned when signing with an account that is not unlocked
+	ErrLocked = errors.New("account is locked")
+	// ErrAccountExists is returned when importing a key the keystore holds
+	ErrAccountExists = errors.New("account already exists")
+)
+
+// MemoryBackend keeps its accounts in memory only, nothing is written to
+// disk, meant for tests and one-off containers
+type MemoryBackend struct {
+	mu       sync.Mutex
+	accounts map[string]*MemoryKeystore
+}
+
+// NewMemoryBackend returns an empty in-memory backend
+func NewMemoryBackend() *MemoryBackend {
+	return &MemoryBackend{accounts: map[string]*MemoryKeystore{}}
+}
+
+func (b *MemoryBackend) Location() string {
+	return "memory"
+}
+
+func (b *MemoryBackend) Names() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	names := []string{}
+	for name, ks := range b.accounts {
+		if len(ks.Accounts()) > 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (b *MemoryBackend) Open(name string) (Keystore, error) {
+	return b.open(name), nil
+}
+
+func (b *MemoryBackend) open(name string) *MemoryKeystore {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	ks, ok := b.accounts[name]
+	if !ok {
+		ks = NewMemoryKeystore()
+		b.accounts[name] = ks
+	}
+	return ks
+}
+
+func (b *MemoryBackend) ImportECDSA(
+	name string, key *ecdsa.PrivateKey, passphrase string,
+) (accounts.Account, error) {
+	return b.open(name).ImportECDSA(key, passphrase)
+}
+
+func (b *MemoryBackend) ImportKeyFile(name, fileName string, keyJSON []byte) error {
+	_, err := b.open(name).ImportKeyJSON(keyJSON)
+	return err
+}
+
+func (b *MemoryBackend) Remove(name string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.accounts, name)
+	return nil
+}
+
+// MemoryKeystore holds encrypted keys in memory, decrypting them only to
+// unlock or sign, as *keystore.KeyStore does with key files
+type MemoryKeystore struct {
+	mu       sync.Mutex
+	order    []ethCommon.Address
+	keys     map[ethCommon.Address][]byte
+	unlocked map[ethCommon.Address]*keystore.Key
+	timers   map[ethCommon.Address]*time.Timer
+}
+
+// NewMemoryKeystore returns an empty in-memory keystore
+func NewMemoryKeystore() *MemoryKeystore {
+	return &MemoryKeystore{
+		keys:     map[ethCommon.Address][]byte{},
+		unlocked: map[ethCommon.Address]*keystore.Key{},
+		timers:   map[ethCommon.Address]*time.Timer{},
+	}
+}
+
+func memoryAccount(addr ethCommon.Address) accounts.Account {
+	return accounts.Account{Address: addr, URL: accounts.URL{Scheme: "memory", Path: addr.Hex()}}
+}
+
+func (ks *MemoryKeystore) Accounts() []accounts.Account {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	all := make([]accounts.Account, len(ks.order))
+	for i, addr := range ks.order {
+		all[i] = memoryAccount(addr)
+	}
+	return all
+}
+
+func (ks *MemoryKeystore) Find(a accounts.Account) (accounts.Account, error) {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	if _, ok := ks.keys[a.Address]; !ok {
+		return accounts.Account{}, ErrNoKey
+	}
+	return memoryAccount(a.Address), nil
+}
+
+func (ks *MemoryKeystore) NewAccount(passphrase string) (accounts.Account, error) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		return accounts.Account{}, err
+	}
+	return ks.ImportECDSA(key, passphrase)
+}
+
+func (ks *MemoryKeystore) ImportECDSA(priv *ecdsa.PrivateKey, passphrase string) (accounts.Account, error) {
+	key := &keystore.Key{
+		Id:         uuid.NewRandom(),
+		Address:    crypto.PubkeyToAddress(priv.PublicKey),
+		PrivateKey: priv,
+	}
+	keyJSON, err := keystore.EncryptKey(key, passphrase, common.ScryptN, common.ScryptP)
+	if err != nil {
+		return accounts.Account{}, err
+	}
+	return ks.add(key.Address, keyJSON)
+}
+
+// ImportKeyJSON stores an already encrypted key file
+func (ks *MemoryKeystore) ImportKeyJSON(keyJSON []byte) (accounts.Account, error) {
+	var file struct {
+		Address string `json:"address"`
+	}
+	if err := json.Unmarshal(keyJSON, &file); err != nil {
+		return accounts.Account{}, fmt.Errorf("invalid key file: %w", err)
+	}
+	if !ethCommon.IsHexAddress(file.Address) {
+		return accounts.Account{}, fmt.Errorf("invalid key file: bad address %q", file.Address)
+	}
+	return ks.add(ethCommon.HexToAddress(file.Address), keyJSON)
+}
+
+func (ks *MemoryKeystore) add(addr ethCommon.Address, keyJSON []byte) (accounts.Account, error) {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	if _, ok := ks.keys[addr]; ok {
+		return accounts.Account{}, ErrAccountExists
+	}
+	ks.keys[addr] = keyJSON
+	ks.order = append(ks.order, addr)
+	return memoryAccount(addr), nil
+}
+
+func (ks *MemoryKeystore) Export(a accounts.Account, passphrase, newPassphrase string) ([]byte, error) {
+	_, key, err := ks.GetDecryptedKey(a, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return keystore.EncryptKey(key, newPassphrase, common.ScryptN, common.ScryptP)
+}
+
+func (ks *MemoryKeystore) GetDecryptedKey(a accounts.Account, auth string) (accounts.Account, *keystore.Key, error) {
+	ks.mu.Lock()
+	keyJSON, ok := ks.keys[a.Address]
+	ks.mu.Unlock()
+	if !ok {
+		return accounts.Account{}, nil, ErrNoKey
+	}
+	key, err := keystore.DecryptKey(keyJSON, auth)
+	if err != nil {
+		return accounts.Account{}, nil, err
+	}
+	return memoryAccount(a.Address), key, nil
+}
+
+func (ks *MemoryKeystore) Lock(addr ethCommon.Address) error {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	if timer, ok := ks.timers[addr]; ok {
+		timer.Stop()
+		delete(ks.timers, addr)
+	}
+	delete(ks.unlocked, addr)
+	return nil
+}
+
+func (ks *MemoryKeystore) Unlock(a accounts.Account, passphrase string) error {
+	return ks.TimedUnlock(a, passphrase, 0)
+}
+
+// TimedUnlock unlocks the account until timeout has passed, a timeout of 0
+// keeps it unlocked until Lock
+func (ks *MemoryKeystore) TimedUnlock(a accounts.Account, passphrase string, timeout time.Duration) error {
+	_, key, err := ks.GetDecryptedKey(a, passphrase)
+	if err != nil {
+		return err
+	}
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	if timer, ok := ks.timers[a.Address]; ok {
+		timer.Stop()
+		delete(ks.timers, a.Address)
+	}
+	ks.unlocked[a.Address] = key
+	if timeout > 0 {
+		ks.timers[a.Address] = time.AfterFunc(timeout, func() { ks.Lock(a.Address) })
+	}
+	return nil
+}
+
+func (ks *MemoryKeystore) unlockedKey(a accounts.Account) (*ecdsa.PrivateKey, error) {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+	key, ok := ks.unlocked[a.Address]
+	if !ok {
+		return nil, ErrLocked
+	}
+	return key.PrivateKey, nil
+}
+
+func (ks *MemoryKeystore) SignHash(a accounts.Account, hash []byte) ([]byte, error) {
+	priv, err := ks.unlockedKey(a)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.Sign(hash, priv)
+}
+
+func (ks *MemoryKeystore) SignHashWithPassphrase(a accounts.Account, passphrase string, hash []byte) ([]byte, error) {
+	_, key, err := ks.GetDecryptedKey(a, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.Sign(hash, key.PrivateKey)
+}
+
+func (ks *MemoryKeystore) SignTx(a accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	priv, err := ks.unlockedKey(a)
+	if err != nil {
+		return nil, err
+	}
+	return types.SignTx(tx, types.NewEIP155Signer(chainID), priv)
+}
+
+func (ks *MemoryKeystore) SignEthTx(
+	a accounts.Account, tx *types.EthTransaction, chainID *big.Int,
+) (*types.EthTransaction, error) {
+	priv, err := ks.unlockedKey(a)
+	if err != nil {
+		return nil, err
+	}
+	return types.SignEthTx(tx, types.NewEIP155Signer(chainID), priv)
+}
+
+func (ks *MemoryKeystore) SignStakingTx(
+	a accounts.Account, tx *staking.StakingTransaction, chainID *big.Int,
+) (*staking.StakingTransaction, error) {
+	priv, err := ks.unlockedKey(a)
+	if err != nil {
+		return nil, err
+	}
+	return staking.Sign(tx, staking.NewEIP155Signer(chainID), priv)
+}
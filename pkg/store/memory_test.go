@@ -0,0 +1,76 @@
+package store
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestMemoryKeystore(t *testing.T) {
+	backend := NewMemoryBackend()
+	if names := backend.Names(); len(names) != 0 {
+		t.Errorf("Names() returned %v, expected []", names)
+	}
+	key, _ := crypto.GenerateKey()
+	account, err := backend.ImportECDSA("foo", key, "secret")
+	if err != nil {
+		t.Fatalf("ImportECDSA failed %v", err)
+	}
+	if account.Address != crypto.PubkeyToAddress(key.PublicKey) {
+		t.Errorf("ImportECDSA returned %s, expected %s", account.Address.Hex(), crypto.PubkeyToAddress(key.PublicKey).Hex())
+	}
+	if _, err := backend.ImportECDSA("foo", key, "secret"); !errors.Is(err, ErrAccountExists) {
+		t.Errorf("ImportECDSA of the same key returned %v, expected %v", err, ErrAccountExists)
+	}
+	if names := backend.Names(); len(names) != 1 || names[0] != "foo" {
+		t.Errorf("Names() returned %v, expected [foo]", names)
+	}
+
+	ks, _ := backend.Open("foo")
+	hash := crypto.Keccak256([]byte("message"))
+	if _, err := ks.SignHash(account, hash); !errors.Is(err, ErrLocked) {
+		t.Errorf("SignHash while locked returned %v, expected %v", err, ErrLocked)
+	}
+	if err := ks.Unlock(account, "wrong"); err == nil {
+		t.Errorf("Unlock with a wrong passphrase succeeded")
+	}
+	if err := ks.Unlock(account, "secret"); err != nil {
+		t.Fatalf("Unlock failed %v", err)
+	}
+	sig, err := ks.SignHash(account, hash)
+	if err != nil {
+		t.Fatalf("SignHash failed %v", err)
+	}
+	if pub, err := crypto.SigToPub(hash, sig); err != nil || crypto.PubkeyToAddress(*pub) != account.Address {
+		t.Errorf("SignHash signature does not recover to %s", account.Address.Hex())
+	}
+	ks.Lock(account.Address)
+	if _, err := ks.SignHash(account, hash); !errors.Is(err, ErrLocked) {
+		t.Errorf("SignHash after Lock returned %v, expected %v", err, ErrLocked)
+	}
+
+	keyJSON, err := ks.Export(account, "secret", "other")
+	if err != nil {
+		t.Fatalf("Export failed %v", err)
+	}
+	if err := backend.ImportKeyFile("bar", "key.json", keyJSON); err != nil {
+		t.Fatalf("ImportKeyFile failed %v", err)
+	}
+	copied, _ := backend.Open("bar")
+	_, decrypted, err := copied.GetDecryptedKey(account, "other")
+	if err != nil {
+		t.Fatalf("GetDecryptedKey failed %v", err)
+	}
+	if !bytes.Equal(crypto.FromECDSA(decrypted.PrivateKey), crypto.FromECDSA(key)) {
+		t.Errorf("GetDecryptedKey returned another key than was imported")
+	}
+
+	if err := backend.Remove("foo"); err != nil {
+		t.Fatalf("Remove failed %v", err)
+	}
+	if names := backend.Names(); len(names) != 1 || names[0] != "bar" {
+		t.Errorf("Names() returned %v after Remove, expected [bar]", names)
+	}
+}
@@ -0,0 +1,165 @@
+package transaction
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/intelchain-itc/itc-sdk/pkg/common"
+	"github.com/intelchain-itc/itc-sdk/pkg/rpc"
+)
+
+const (
+	defaultConfirmationBackoff    = 500 * time.Millisecond
+	defaultConfirmationMaxBackoff = 8 * time.Second
+)
+
+var (
+	// ErrConfirmationTimeout is returned when a transaction could not be
+	// confirmed at the required depth before the timeout elapsed.
+	ErrConfirmationTimeout = errors.New("could not confirm transaction")
+	// ErrTransactionFailed is returned when a transaction was included in a
+	// block, but its receipt reports a failed execution.
+	ErrTransactionFailed = errors.New("transaction execution failed")
+)
+
+// ConfirmationOptions controls how WaitForConfirmation polls for a receipt
+type ConfirmationOptions struct {
+	// Timeout is the overall time to wait for the required confirmations
+	Timeout time.Duration
+	// Confirmations is the required block depth of the receipt, where the
+	// block including the transaction counts as the first confirmation
+	Confirmations uint64
+	// Backoff is the initial poll interval, doubled after every poll
+	Backoff time.Duration
+	// MaxBackoff caps the poll interval
+	MaxBackoff time.Duration
+}
+
+// NewConfirmationOptions returns the ConfirmationOptions for a timeout in
+// seconds and a required number of confirmations, using default backoffs
+func NewConfirmationOptions(timeout, confirmations uint32) ConfirmationOptions {
+	if confirmations == 0 {
+		confirmations = 1
+	}
+	return ConfirmationOptions{
+		Timeout:       time.Duration(timeout) * time.Second,
+		Confirmations: uint64(confirmations),
+		Backoff:       defaultConfirmationBackoff,
+		MaxBackoff:    defaultConfirmationMaxBackoff,
+	}
+}
+
+// replyUint64 reads a quantity of a node reply, which was decoded into an
+// interface{}, as a uint64
+func replyUint64(v interface{}) (uint64, bool) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return 0, false
+	}
+	n, ok := common.Quantity(raw)
+	if !ok || !n.IsUint64() {
+		return 0, false
+	}
+	return n.Uint64(), true
+}
+
+func currentBlockNumber(messenger rpc.T) (uint64, error) {
+	reply, err := messenger.SendRPC(rpc.Method.BlockNumber, p{})
+	if err != nil {
+		return 0, err
+	}
+	number, ok := replyUint64(reply["result"])
+	if !ok {
+		return 0, fmt.Errorf("could not parse block number %v", reply["result"])
+	}
+	return number, nil
+}
+
+// WaitForConfirmation polls for the receipt of txHash with exponential
+// backoff until it is buried under the required number of blocks. A receipt
+// that disappears or moves to another block is treated as a reorg and the
+// depth count restarts. Errors reported by the node's error sinks for the
+// transaction are returned alongside the error. The receipt is returned
+// whenever one was seen, including when its status reports a failure.
+func WaitForConfirmation(
+	messenger rpc.T, txHash string, options ConfirmationOptions,
+) (rpc.Reply, Errors, error) {
+	var (
+		seenBlockHash string
+		reorged       bool
+		txErrors      Errors
+	)
+	deadline := time.Now().Add(options.Timeout)
+	backoff := options.Backoff
+	if backoff <= 0 {
+		backoff = defaultConfirmationBackoff
+	}
+	for {
+		r, _ := messenger.SendRPC(rpc.Method.GetTransactionReceipt, p{txHash})
+		if receipt, ok := r["result"].(map[string]interface{}); ok {
+			blockHash, _ := receipt["blockHash"].(string)
+			if seenBlockHash != "" && blockHash != seenBlockHash {
+				reorged = true
+				if common.DebugTransaction {
+					fmt.Printf("receipt of %s moved from block %s to %s\n", txHash, seenBlockHash, blockHash)
+				}
+			}
+			seenBlockHash = blockHash
+			receiptBlock, ok := replyUint64(receipt["blockNumber"])
+			if !ok {
+				return r, txErrors, fmt.Errorf("could not parse block number of receipt for %s", txHash)
+			}
+			current, err := currentBlockNumber(messenger)
+			if err != nil {
+				return r, txErrors, err
+			}
+			if current+1 >= receiptBlock+options.Confirmations {
+				if status, ok := replyUint64(receipt["status"]); ok && status == 0 {
+					return r, txErrors, fmt.Errorf("%w: %s in block %d", ErrTransactionFailed, txHash, receiptBlock)
+				}
+				return r, txErrors, nil
+			}
+		} else {
+			if seenBlockHash != "" {
+				reorged = true
+				seenBlockHash = ""
+				if common.DebugTransaction {
+					fmt.Printf("receipt of %s disappeared\n", txHash)
+				}
+			}
+			sinkErrors, err := GetError(txHash, messenger)
+			if err != nil {
+				errMsg := err.Error()
+				txErrors = append(txErrors, &Error{
+					TxHashID:             &txHash,
+					ErrMessage:           &errMsg,
+					TimestampOfRejection: time.Now().Unix(),
+				})
+			}
+			if len(sinkErrors) > 0 {
+				txErrors = append(txErrors, sinkErrors...)
+				return nil, txErrors, fmt.Errorf("error found for transaction hash: %s", txHash)
+			}
+		}
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			if reorged {
+				return nil, txErrors, fmt.Errorf(
+					"%w: %s after %s, its receipt was reorged", ErrConfirmationTimeout, txHash, options.Timeout,
+				)
+			}
+			return nil, txErrors, fmt.Errorf("%w: %s after %s", ErrConfirmationTimeout, txHash, options.Timeout)
+		}
+		if backoff > remaining {
+			time.Sleep(remaining)
+		} else {
+			time.Sleep(backoff)
+		}
+		backoff *= 2
+		if options.MaxBackoff > 0 && backoff > options.MaxBackoff {
+			backoff = options.MaxBackoff
+		}
+	}
+}
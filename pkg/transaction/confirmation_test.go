@@ -0,0 +1,138 @@
+package transaction
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/intelchain-itc/itc-sdk/pkg/rpc"
+)
+
+type scriptedMessenger struct {
+	receipts []map[string]interface{}
+	heads    []uint64
+	polls    int
+	blocks   int
+}
+
+func (m *scriptedMessenger) SendRPC(method string, params []interface{}) (rpc.Reply, error) {
+	switch method {
+	case rpc.Method.GetTransactionReceipt:
+		i := m.polls
+		if i >= len(m.receipts) {
+			i = len(m.receipts) - 1
+		}
+		m.polls++
+		if m.receipts[i] == nil {
+			return rpc.Reply{"result": nil}, nil
+		}
+		return rpc.Reply{"result": m.receipts[i]}, nil
+	case rpc.Method.BlockNumber:
+		i := m.blocks
+		if i >= len(m.heads) {
+			i = len(m.heads) - 1
+		}
+		m.blocks++
+		return rpc.Reply{"result": hexutil.EncodeUint64(m.heads[i])}, nil
+	default:
+		return rpc.Reply{"result": []interface{}{}}, nil
+	}
+}
+
+func receiptAt(block uint64, hash string, status uint64) map[string]interface{} {
+	return map[string]interface{}{
+		"blockNumber": hexutil.EncodeUint64(block),
+		"blockHash":   hash,
+		"status":      hexutil.EncodeUint64(status),
+	}
+}
+
+func testOptions(confirmations uint64) ConfirmationOptions {
+	return ConfirmationOptions{
+		Timeout:       50 * time.Millisecond,
+		Confirmations: confirmations,
+		Backoff:       time.Millisecond,
+		MaxBackoff:    time.Millisecond,
+	}
+}
+
+func TestWaitForConfirmation(t *testing.T) {
+	tests := []struct {
+		name          string
+		messenger     *scriptedMessenger
+		confirmations uint64
+		expectedErr   error
+		expectedHash  string
+	}{
+		{
+			name: "included",
+			messenger: &scriptedMessenger{
+				receipts: []map[string]interface{}{nil, receiptAt(10, "0xa", 1)},
+				heads:    []uint64{10},
+			},
+			confirmations: 1,
+			expectedHash:  "0xa",
+		},
+		{
+			name: "waits for depth",
+			messenger: &scriptedMessenger{
+				receipts: []map[string]interface{}{receiptAt(10, "0xa", 1)},
+				heads:    []uint64{10, 11, 12},
+			},
+			confirmations: 3,
+			expectedHash:  "0xa",
+		},
+		{
+			name: "not deep enough",
+			messenger: &scriptedMessenger{
+				receipts: []map[string]interface{}{receiptAt(10, "0xa", 1)},
+				heads:    []uint64{10},
+			},
+			confirmations: 3,
+			expectedErr:   ErrConfirmationTimeout,
+		},
+		{
+			name: "reorged into another block",
+			messenger: &scriptedMessenger{
+				receipts: []map[string]interface{}{receiptAt(10, "0xa", 1), nil, receiptAt(11, "0xb", 1)},
+				heads:    []uint64{10, 10, 12},
+			},
+			confirmations: 2,
+			expectedHash:  "0xb",
+		},
+		{
+			name: "failed execution",
+			messenger: &scriptedMessenger{
+				receipts: []map[string]interface{}{receiptAt(10, "0xa", 0)},
+				heads:    []uint64{10},
+			},
+			confirmations: 1,
+			expectedErr:   ErrTransactionFailed,
+			expectedHash:  "0xa",
+		},
+		{
+			name: "never included",
+			messenger: &scriptedMessenger{
+				receipts: []map[string]interface{}{nil},
+				heads:    []uint64{10},
+			},
+			confirmations: 1,
+			expectedErr:   ErrConfirmationTimeout,
+		},
+	}
+
+	for _, test := range tests {
+		receipt, _, err := WaitForConfirmation(test.messenger, "0x01", testOptions(test.confirmations))
+		if !errors.Is(err, test.expectedErr) {
+			t.Errorf("%s: WaitForConfirmation returned error %v, expected %v", test.name, err, test.expectedErr)
+		}
+		hash := ""
+		if receipt != nil {
+			hash, _ = receipt["result"].(map[string]interface{})["blockHash"].(string)
+		}
+		if hash != test.expectedHash {
+			t.Errorf("%s: WaitForConfirmation returned receipt in block %q, expected %q", test.name, hash, test.expectedHash)
+		}
+	}
+}
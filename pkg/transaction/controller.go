@@ -10,7 +10,6 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/intelchain-itc/intelchain/accounts"
-	"github.com/intelchain-itc/intelchain/accounts/keystore"
 	"github.com/intelchain-itc/intelchain/common/denominations"
 	"github.com/intelchain-itc/intelchain/core/types"
 	"github.com/intelchain-itc/intelchain/numeric"
@@ -18,6 +17,7 @@ import (
 	"github.com/intelchain-itc/itc-sdk/pkg/common"
 	"github.com/intelchain-itc/itc-sdk/pkg/ledger"
 	"github.com/intelchain-itc/itc-sdk/pkg/rpc"
+	"github.com/intelchain-itc/itc-sdk/pkg/store"
 )
 
 var (
@@ -41,7 +41,7 @@ type transactionForRPC struct {
 }
 
 type sender struct {
-	ks      *keystore.KeyStore
+	ks      store.Keystore
 	account *accounts.Account
 }
 
@@ -61,11 +61,17 @@ type behavior struct {
 	OfflineSign          bool
 	SigningImpl          SignerImpl
 	ConfirmationWaitTime uint32
+	// Confirmations is the block depth a receipt needs before the transaction
+	// counts as confirmed, the including block being the first
+	Confirmations uint32
+	// Simulate runs transactions carrying input data through Call and
+	// EstimateGas before they are signed, refusing to send them on failure
+	Simulate bool
 }
 
 // NewController initializes a Controller, caller can control behavior via options
 func NewController(
-	handler rpc.T, senderKs *keystore.KeyStore,
+	handler rpc.T, senderKs store.Keystore,
 	senderAcct *accounts.Account, chain common.ChainID,
 	options ...func(*Controller),
 ) *Controller {
@@ -84,7 +90,7 @@ func NewController(
 			receipt:         nil,
 		},
 		chain:    chain,
-		Behavior: behavior{false, false, Software, 0},
+		Behavior: behavior{SigningImpl: Software},
 	}
 	for _, option := range options {
 		option(ctrlr)
@@ -103,6 +109,9 @@ func (C *Controller) TransactionToJSON(pretty bool) string {
 
 // RawTransaction dumps the signature as string
 func (C *Controller) RawTransaction() string {
+	if C.transactionForRPC.signature == nil {
+		return ""
+	}
 	return *C.transactionForRPC.signature
 }
 
@@ -237,6 +246,30 @@ func (C *Controller) setNewTransactionWithDataAndGas(data []byte) {
 	)
 }
 
+func (C *Controller) simulateTransaction(data []byte) {
+	if C.executionError != nil || !C.Behavior.Simulate || C.Behavior.OfflineSign || len(data) == 0 {
+		return
+	}
+	args := callArgs{
+		From:     C.sender.account.Address,
+		Gas:      hexutil.Uint64(C.transactionForRPC.params["gas-limit"].(uint64)),
+		GasPrice: (*hexutil.Big)(C.transactionForRPC.params["gas-price"].(numeric.Dec).TruncateInt()),
+		Value:    (*hexutil.Big)(C.transactionForRPC.params["transfer-amount"].(numeric.Dec).TruncateInt()),
+		Data:     data,
+	}
+	if C.transactionForRPC.params["receiver"] != nil {
+		args.To = C.transactionForRPC.params["receiver"].(*address.T)
+	}
+	if err := simulate(C.messenger, args); err != nil {
+		C.executionError = ErrSimulationFailed
+		errorMsg := err.Error()
+		C.transactionErrors = append(C.transactionErrors, &Error{
+			ErrMessage:           &errorMsg,
+			TimestampOfRejection: time.Now().Unix(),
+		})
+	}
+}
+
 func (C *Controller) signAndPrepareTxEncodedForSending() {
 	if C.executionError != nil {
 		return
@@ -299,33 +332,16 @@ func (C *Controller) txConfirmation() {
 	}
 	if C.Behavior.ConfirmationWaitTime > 0 {
 		txHash := *C.TransactionHash()
-		start := int(C.Behavior.ConfirmationWaitTime)
-		for {
-			r, _ := C.messenger.SendRPC(rpc.Method.GetTransactionReceipt, p{txHash})
-			if r["result"] != nil {
-				C.transactionForRPC.receipt = r
-				return
-			}
-			transactionErrors, err := GetError(txHash, C.messenger)
-			if err != nil {
-				errMsg := fmt.Sprintf(err.Error())
-				C.transactionErrors = append(C.transactionErrors, &Error{
-					TxHashID:             &txHash,
-					ErrMessage:           &errMsg,
-					TimestampOfRejection: time.Now().Unix(),
-				})
-			}
-			C.transactionErrors = append(C.transactionErrors, transactionErrors...)
-			if len(transactionErrors) > 0 {
-				C.executionError = fmt.Errorf("error found for transaction hash: %s", txHash)
-				return
-			}
-			if start < 0 {
-				C.executionError = fmt.Errorf("could not confirm transaction after %d seconds", C.Behavior.ConfirmationWaitTime)
-				return
-			}
-			time.Sleep(time.Second)
-			start--
+		receipt, transactionErrors, err := WaitForConfirmation(
+			C.messenger, txHash,
+			NewConfirmationOptions(C.Behavior.ConfirmationWaitTime, C.Behavior.Confirmations),
+		)
+		C.transactionErrors = append(C.transactionErrors, transactionErrors...)
+		if receipt != nil {
+			C.transactionForRPC.receipt = receipt
+		}
+		if err != nil {
+			C.executionError = err
 		}
 	}
 }
@@ -348,6 +364,7 @@ func (C *Controller) ExecuteTransaction(
 	C.setReceiver(to)
 	C.transactionForRPC.params["nonce"] = nonce
 	C.setNewTransactionWithDataAndGas(inputData)
+	C.simulateTransaction(inputData)
 	switch C.Behavior.SigningImpl {
 	case Software:
 		C.signAndPrepareTxEncodedForSending()
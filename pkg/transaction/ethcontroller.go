@@ -8,12 +8,12 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/intelchain-itc/intelchain/accounts"
-	"github.com/intelchain-itc/intelchain/accounts/keystore"
 	"github.com/intelchain-itc/intelchain/core/types"
 	"github.com/intelchain-itc/intelchain/numeric"
 	"github.com/intelchain-itc/itc-sdk/pkg/address"
 	"github.com/intelchain-itc/itc-sdk/pkg/common"
 	"github.com/intelchain-itc/itc-sdk/pkg/rpc"
+	"github.com/intelchain-itc/itc-sdk/pkg/store"
 )
 
 type ethTransactionForRPC struct {
@@ -38,7 +38,7 @@ type EthController struct {
 
 // NewEthController initializes a EthController, caller can control behavior via options
 func NewEthController(
-	handler rpc.T, senderKs *keystore.KeyStore,
+	handler rpc.T, senderKs store.Keystore,
 	senderAcct *accounts.Account, chain common.ChainID,
 	options ...func(*EthController),
 ) *EthController {
@@ -57,7 +57,7 @@ func NewEthController(
 			receipt:         nil,
 		},
 		chain:    chain,
-		Behavior: behavior{false, false, Software, 0},
+		Behavior: behavior{SigningImpl: Software},
 	}
 	for _, option := range options {
 		option(ctrlr)
@@ -76,6 +76,9 @@ func (C *EthController) EthTransactionToJSON(pretty bool) string {
 
 // RawTransaction dumps the signature as string
 func (C *EthController) RawTransaction() string {
+	if C.transactionForRPC.signature == nil {
+		return ""
+	}
 	return *C.transactionForRPC.signature
 }
 
@@ -184,12 +187,35 @@ func (C *EthController) setNewTransactionWithDataAndGas(data []byte) {
 	)
 }
 
+func (C *EthController) simulateTransaction(data []byte) {
+	if C.executionError != nil || !C.Behavior.Simulate || len(data) == 0 {
+		return
+	}
+	receiver := C.transactionForRPC.params["receiver"].(address.T)
+	args := callArgs{
+		From:     C.sender.account.Address,
+		To:       &receiver,
+		Gas:      hexutil.Uint64(C.transactionForRPC.params["gas-limit"].(uint64)),
+		GasPrice: (*hexutil.Big)(C.transactionForRPC.params["gas-price"].(numeric.Dec).TruncateInt()),
+		Value:    (*hexutil.Big)(C.transactionForRPC.params["transfer-amount"].(numeric.Dec).TruncateInt()),
+		Data:     data,
+	}
+	if err := simulate(C.messenger, args); err != nil {
+		C.executionError = ErrSimulationFailed
+		errorMsg := err.Error()
+		C.transactionErrors = append(C.transactionErrors, &Error{
+			ErrMessage:           &errorMsg,
+			TimestampOfRejection: time.Now().Unix(),
+		})
+	}
+}
+
 func (C *EthController) signAndPrepareTxEncodedForSending() {
 	if C.executionError != nil {
 		return
 	}
 	signedTransaction, err :=
-		C.sender.ks.SignEthTx(*C.sender.account, C.transactionForRPC.transaction, C.chain.Value)
+		C.sender.ks.SignEthTx(*C.sender.account, C.transactionForRPC.transaction, C.chain.EthID())
 	if err != nil {
 		C.executionError = err
 		return
@@ -209,7 +235,7 @@ func (C *EthController) signAndPrepareTxEncodedForSending() {
 	if C.executionError != nil {
 		return
 	}
-	enc, signerAddr, err := ledger.SignEthTx(C.transactionForRPC.transaction, C.chain.Value)
+	enc, signerAddr, err := ledger.SignEthTx(C.transactionForRPC.transaction, C.chain.EthID())
 	if err != nil {
 		C.executionError = err
 		return
@@ -246,33 +272,16 @@ func (C *EthController) txConfirmation() {
 	}
 	if C.Behavior.ConfirmationWaitTime > 0 {
 		txHash := *C.TransactionHash()
-		start := int(C.Behavior.ConfirmationWaitTime)
-		for {
-			r, _ := C.messenger.SendRPC(rpc.Method.GetTransactionReceipt, p{txHash})
-			if r["result"] != nil {
-				C.transactionForRPC.receipt = r
-				return
-			}
-			transactionErrors, err := GetError(txHash, C.messenger)
-			if err != nil {
-				errMsg := fmt.Sprintf(err.Error())
-				C.transactionErrors = append(C.transactionErrors, &Error{
-					TxHashID:             &txHash,
-					ErrMessage:           &errMsg,
-					TimestampOfRejection: time.Now().Unix(),
-				})
-			}
-			C.transactionErrors = append(C.transactionErrors, transactionErrors...)
-			if len(transactionErrors) > 0 {
-				C.executionError = fmt.Errorf("error found for transaction hash: %s", txHash)
-				return
-			}
-			if start < 0 {
-				C.executionError = fmt.Errorf("could not confirm transaction after %d seconds", C.Behavior.ConfirmationWaitTime)
-				return
-			}
-			time.Sleep(time.Second)
-			start--
+		receipt, transactionErrors, err := WaitForConfirmation(
+			C.messenger, txHash,
+			NewConfirmationOptions(C.Behavior.ConfirmationWaitTime, C.Behavior.Confirmations),
+		)
+		C.transactionErrors = append(C.transactionErrors, transactionErrors...)
+		if receipt != nil {
+			C.transactionForRPC.receipt = receipt
+		}
+		if err != nil {
+			C.executionError = err
 		}
 	}
 }
@@ -293,6 +302,7 @@ func (C *EthController) ExecuteEthTransaction(
 	C.setReceiver(to)
 	C.transactionForRPC.params["nonce"] = nonce
 	C.setNewTransactionWithDataAndGas(inputData)
+	C.simulateTransaction(inputData)
 	switch C.Behavior.SigningImpl {
 	case Software:
 		C.signAndPrepareTxEncodedForSending()
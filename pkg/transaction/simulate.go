@@ -0,0 +1,146 @@
+package transaction
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/intelchain-itc/itc-sdk/pkg/address"
+	"github.com/intelchain-itc/itc-sdk/pkg/rpc"
+)
+
+var (
+	// ErrSimulationFailed is returned when the pre-flight simulation of a
+	// transaction shows that it would fail once included in a block.
+	ErrSimulationFailed = errors.New("transaction failed pre-flight simulation")
+
+	errorSelector = []byte{0x08, 0xc3, 0x79, 0xa0} // Error(string)
+	panicSelector = []byte{0x4e, 0x48, 0x7b, 0x71} // Panic(uint256)
+
+	panicReasons = map[uint64]string{
+		0x00: "generic compiler inserted panic",
+		0x01: "assertion failed",
+		0x11: "arithmetic underflow or overflow",
+		0x12: "division or modulo by zero",
+		0x21: "conversion to an invalid enum value",
+		0x22: "access to an incorrectly encoded storage byte array",
+		0x31: "pop on an empty array",
+		0x32: "array index out of bounds",
+		0x41: "too much memory allocated",
+		0x51: "call to a zero-initialized function",
+	}
+)
+
+type callArgs struct {
+	From     address.T      `json:"from"`
+	To       *address.T     `json:"to,omitempty"`
+	Gas      hexutil.Uint64 `json:"gas"`
+	GasPrice *hexutil.Big   `json:"gasPrice"`
+	Value    *hexutil.Big   `json:"value"`
+	Data     hexutil.Bytes  `json:"data"`
+}
+
+// DecodeRevertReason decodes the return data of a reverted call, which is
+// either an Error(string) or a Panic(uint256) as emitted by solidity
+func DecodeRevertReason(data []byte) (string, error) {
+	if len(data) < 4 {
+		return "", errors.New("revert data too short")
+	}
+	selector, payload := data[:4], data[4:]
+	switch {
+	case bytes.Equal(selector, errorSelector):
+		if len(payload) < 64 {
+			return "", errors.New("malformed Error(string) revert data")
+		}
+		offset := new(big.Int).SetBytes(payload[:32])
+		if !offset.IsUint64() || offset.Uint64()+32 > uint64(len(payload)) {
+			return "", errors.New("invalid string offset in revert data")
+		}
+		start := offset.Uint64()
+		size := new(big.Int).SetBytes(payload[start : start+32])
+		if !size.IsUint64() || start+32+size.Uint64() > uint64(len(payload)) {
+			return "", errors.New("invalid string length in revert data")
+		}
+		return string(payload[start+32 : start+32+size.Uint64()]), nil
+	case bytes.Equal(selector, panicSelector):
+		if len(payload) < 32 {
+			return "", errors.New("malformed Panic(uint256) revert data")
+		}
+		code := new(big.Int).SetBytes(payload[:32])
+		if code.IsUint64() {
+			if reason, ok := panicReasons[code.Uint64()]; ok {
+				return fmt.Sprintf("panic 0x%x: %s", code.Uint64(), reason), nil
+			}
+		}
+		return fmt.Sprintf("panic 0x%x", code), nil
+	default:
+		return "", fmt.Errorf("unknown revert selector 0x%x", binary.BigEndian.Uint32(selector))
+	}
+}
+
+// isRevertData reports whether the return data of a call looks like an
+// encoded Error(string) or Panic(uint256). Regular ABI encoded return values
+// are always a multiple of 32 bytes, revert payloads are 4 bytes longer.
+func isRevertData(data []byte) bool {
+	if len(data) < 36 || len(data)%32 != 4 {
+		return false
+	}
+	return bytes.HasPrefix(data, errorSelector) || bytes.HasPrefix(data, panicSelector)
+}
+
+// revertReasonFromError extracts the revert reason from an RPC error, using
+// the error data when the node provides it
+func revertReasonFromError(err error) string {
+	withData, ok := err.(*rpc.ErrorWithData)
+	if !ok {
+		return err.Error()
+	}
+	encoded, ok := withData.Data.(string)
+	if !ok {
+		return err.Error()
+	}
+	data, decodeErr := hexutil.Decode(encoded)
+	if decodeErr != nil {
+		return err.Error()
+	}
+	reason, decodeErr := DecodeRevertReason(data)
+	if decodeErr != nil {
+		return err.Error()
+	}
+	return fmt.Sprintf("execution reverted: %s", reason)
+}
+
+// simulate runs the transaction described by args through Call and
+// EstimateGas at the pending block. It returns a non-nil error describing
+// why the transaction would fail, or nil if it is expected to succeed.
+func simulate(messenger rpc.T, args callArgs) error {
+	reply, err := messenger.SendRPC(rpc.Method.Call, p{args, "pending"})
+	if err != nil {
+		return errors.New(revertReasonFromError(err))
+	}
+	if result, ok := reply["result"].(string); ok {
+		if ret, err := hexutil.Decode(result); err == nil && isRevertData(ret) {
+			reason, err := DecodeRevertReason(ret)
+			if err != nil {
+				return err
+			}
+			return fmt.Errorf("execution reverted: %s", reason)
+		}
+	}
+	reply, err = messenger.SendRPC(rpc.Method.EstimateGas, p{args})
+	if err != nil {
+		return errors.New(revertReasonFromError(err))
+	}
+	estimate, _ := reply["result"].(string)
+	gas, err := hexutil.DecodeUint64(estimate)
+	if err != nil {
+		return fmt.Errorf("could not parse gas estimate %s: %w", estimate, err)
+	}
+	if gas > uint64(args.Gas) {
+		return fmt.Errorf("gas limit of %d is below the estimated %d gas", uint64(args.Gas), gas)
+	}
+	return nil
+}
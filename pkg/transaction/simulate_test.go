@@ -0,0 +1,73 @@
+package transaction
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestDecodeRevertReason(t *testing.T) {
+	tests := []struct {
+		data     string
+		expected string
+		valid    bool
+	}{
+		{
+			// Error("Not enough Ether provided.")
+			"0x08c379a0" +
+				"0000000000000000000000000000000000000000000000000000000000000020" +
+				"000000000000000000000000000000000000000000000000000000000000001a" +
+				"4e6f7420656e6f7567682045746865722070726f76696465642e000000000000",
+			"Not enough Ether provided.", true,
+		},
+		{
+			// Panic(0x11)
+			"0x4e487b71" +
+				"0000000000000000000000000000000000000000000000000000000000000011",
+			"panic 0x11: arithmetic underflow or overflow", true,
+		},
+		{
+			// Panic(0x99), unknown code
+			"0x4e487b71" +
+				"0000000000000000000000000000000000000000000000000000000000000099",
+			"panic 0x99", true,
+		},
+		{
+			// Error(string) with a length running past the payload
+			"0x08c379a0" +
+				"0000000000000000000000000000000000000000000000000000000000000020" +
+				"00000000000000000000000000000000000000000000000000000000000000ff",
+			"", false,
+		},
+		{"0xdeadbeef", "", false},
+		{"0x08c3", "", false},
+	}
+
+	for _, test := range tests {
+		reason, err := DecodeRevertReason(hexutil.MustDecode(test.data))
+		if (err == nil) != test.valid {
+			t.Errorf("DecodeRevertReason(%s) returned error %v, expected valid %v", test.data, err, test.valid)
+			continue
+		}
+		if reason != test.expected {
+			t.Errorf("DecodeRevertReason(%s) returned %q, expected %q", test.data, reason, test.expected)
+		}
+	}
+}
+
+func TestIsRevertData(t *testing.T) {
+	tests := []struct {
+		data     string
+		expected bool
+	}{
+		{"0x4e487b710000000000000000000000000000000000000000000000000000000000000001", true},
+		{"0x0000000000000000000000000000000000000000000000000000000000000001", false},
+		{"0x4e487b71", false},
+	}
+
+	for _, test := range tests {
+		if got := isRevertData(hexutil.MustDecode(test.data)); got != test.expected {
+			t.Errorf("isRevertData(%s) returned %v, expected %v", test.data, got, test.expected)
+		}
+	}
+}
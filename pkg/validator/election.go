@@ -0,0 +1,121 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/intelchain-itc/itc-sdk/pkg/rpc"
+)
+
+var (
+	// ErrBidNoKeys is returned when a bid would leave a validator without keys
+	// at auction
+	ErrBidNoKeys = errors.New("the bid leaves the validator without bls keys at auction")
+	// ErrBidNoStake is returned when a bid would leave a validator without
+	// stake
+	ErrBidNoStake = errors.New("the bid leaves the validator without stake")
+)
+
+// Candidate is a validator bidding for slots in the next election, the
+// stake is in atto
+type Candidate struct {
+	Validator string   `json:"validator"`
+	Stake     *big.Int `json:"stake"`
+	Keys      []string `json:"keys-at-auction"`
+}
+
+// Snapshot is the node's median raw stake snapshot of the next election
+type Snapshot struct {
+	MedianStake      string      `json:"epos-median-stake"`
+	MaxExternalSlots int         `json:"max-external-slots"`
+	Candidates       []Candidate `json:"epos-slot-candidates"`
+}
+
+// Bid is a hypothetical change to the candidates: a new validator, more or
+// less stake, or keys added to or removed from the auction
+type Bid struct {
+	Validator  string
+	Stake      *big.Int
+	AddKeys    []string
+	RemoveKeys []string
+}
+
+// MedianStakeSnapshot fetches the median raw stake snapshot from node
+func MedianStakeSnapshot(node string) (*Snapshot, error) {
+	snapshot := &Snapshot{}
+	if err := rpc.RequestResult(rpc.Method.GetMedianRawStakeSnapshot, node, []interface{}{}, snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// WithBid returns the candidates of the snapshot with the bid applied, the
+// snapshot itself is left as it is. A validator missing from the snapshot
+// joins the auction as a new candidate.
+func (s *Snapshot) WithBid(bid Bid) ([]Candidate, error) {
+	owners := map[string]string{}
+	candidates := []Candidate{}
+	found := false
+	for _, c := range s.Candidates {
+		keys := []string{}
+		for _, k := range c.Keys {
+			keys = append(keys, NormalizeBLSKey(k))
+			owners[NormalizeBLSKey(k)] = c.Validator
+		}
+		stake := new(big.Int)
+		if c.Stake != nil {
+			stake.Set(c.Stake)
+		}
+		if c.Validator == bid.Validator {
+			found = true
+		}
+		candidates = append(candidates, Candidate{c.Validator, stake, keys})
+	}
+	if !found {
+		candidates = append(candidates, Candidate{bid.Validator, new(big.Int), []string{}})
+	}
+
+	for i := range candidates {
+		c := &candidates[i]
+		if c.Validator != bid.Validator {
+			continue
+		}
+		if bid.Stake != nil {
+			c.Stake.Add(c.Stake, bid.Stake)
+		}
+		for _, k := range bid.RemoveKeys {
+			k = NormalizeBLSKey(k)
+			if owners[k] != bid.Validator {
+				return nil, fmt.Errorf("bls key %s is not at auction for %s", k, bid.Validator)
+			}
+			delete(owners, k)
+			c.Keys = removeKey(c.Keys, k)
+		}
+		for _, k := range bid.AddKeys {
+			k = NormalizeBLSKey(k)
+			if owner, taken := owners[k]; taken {
+				return nil, fmt.Errorf("bls key %s is already at auction for %s", k, owner)
+			}
+			owners[k] = bid.Validator
+			c.Keys = append(c.Keys, k)
+		}
+		if len(c.Keys) == 0 {
+			return nil, ErrBidNoKeys
+		}
+		if c.Stake.Sign() <= 0 {
+			return nil, ErrBidNoStake
+		}
+	}
+	return candidates, nil
+}
+
+func removeKey(keys []string, key string) []string {
+	kept := []string{}
+	for _, k := range keys {
+		if k != key {
+			kept = append(kept, k)
+		}
+	}
+	return kept
+}
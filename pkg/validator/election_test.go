@@ -0,0 +1,68 @@
+package validator
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+const testSnapshot = `{
+  "epos-median-stake": "1000.000000000000000000",
+  "max-external-slots": 3,
+  "epos-slot-candidates": [
+    {"validator": "itc1a", "stake": 3000, "keys-at-auction": ["0xAA", "bb"], "stake-per-key": 1500},
+    {"validator": "itc1b", "stake": 800, "keys-at-auction": ["cc"], "stake-per-key": 800}
+  ]
+}`
+
+func TestSnapshotWithBid(t *testing.T) {
+	snapshot := &Snapshot{}
+	if err := json.Unmarshal([]byte(testSnapshot), snapshot); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		bid      Bid
+		expected []Candidate
+		err      error
+	}{
+		{Bid{Validator: "itc1b", Stake: big.NewInt(200), AddKeys: []string{"DD"}}, []Candidate{
+			{"itc1a", big.NewInt(3000), []string{"aa", "bb"}},
+			{"itc1b", big.NewInt(1000), []string{"cc", "dd"}},
+		}, nil},
+		{Bid{Validator: "itc1a", RemoveKeys: []string{"0xaa"}}, []Candidate{
+			{"itc1a", big.NewInt(3000), []string{"bb"}},
+			{"itc1b", big.NewInt(800), []string{"cc"}},
+		}, nil},
+		{Bid{Validator: "itc1c", Stake: big.NewInt(500), AddKeys: []string{"ee"}}, []Candidate{
+			{"itc1a", big.NewInt(3000), []string{"aa", "bb"}},
+			{"itc1b", big.NewInt(800), []string{"cc"}},
+			{"itc1c", big.NewInt(500), []string{"ee"}},
+		}, nil},
+		{Bid{Validator: "itc1c", Stake: big.NewInt(500), AddKeys: []string{"cc"}}, nil, nil},
+		{Bid{Validator: "itc1b", RemoveKeys: []string{"aa"}}, nil, nil},
+		{Bid{Validator: "itc1b", RemoveKeys: []string{"cc"}}, nil, ErrBidNoKeys},
+		{Bid{Validator: "itc1c", AddKeys: []string{"ee"}}, nil, ErrBidNoStake},
+		{Bid{Validator: "itc1b", Stake: big.NewInt(-800)}, nil, ErrBidNoStake},
+	}
+	for _, test := range tests {
+		candidates, err := snapshot.WithBid(test.bid)
+		if test.expected == nil {
+			if err == nil || (test.err != nil && !errors.Is(err, test.err)) {
+				t.Errorf("WithBid(%+v) returned %v, expected error %v", test.bid, err, test.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("WithBid(%+v) failed %v", test.bid, err)
+			continue
+		}
+		if !reflect.DeepEqual(candidates, test.expected) {
+			t.Errorf("WithBid(%+v) returned %+v, expected %+v", test.bid, candidates, test.expected)
+		}
+	}
+	if snapshot.Candidates[1].Stake.Int64() != 800 || len(snapshot.Candidates[0].Keys) != 2 {
+		t.Errorf("WithBid changed the snapshot to %+v", snapshot.Candidates)
+	}
+}
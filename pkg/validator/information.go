@@ -0,0 +1,175 @@
+package validator
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	bls_core "github.com/intelchain-itc/bls/ffi/go/bls"
+	"github.com/intelchain-itc/intelchain/crypto/bls"
+	"github.com/intelchain-itc/itc-sdk/pkg/rpc"
+)
+
+// Information is the part of a node's validator information the sdk acts on
+type Information struct {
+	Validator struct {
+		Address            string   `json:"address"`
+		BLSPublicKeys      []string `json:"bls-public-keys"`
+		Name               string   `json:"name"`
+		Identity           string   `json:"identity"`
+		Website            string   `json:"website"`
+		SecurityContact    string   `json:"security-contact"`
+		Details            string   `json:"details"`
+		Rate               string   `json:"rate"`
+		MaxRate            string   `json:"max-rate"`
+		MaxChangeRate      string   `json:"max-change-rate"`
+		MinSelfDelegation  *big.Int `json:"min-self-delegation"`
+		MaxTotalDelegation *big.Int `json:"max-total-delegation"`
+	} `json:"validator"`
+	Performance          *Performance `json:"current-epoch-performance"`
+	Metrics              *Metrics     `json:"metrics"`
+	TotalDelegation      *big.Int     `json:"total-delegation"`
+	CurrentlyInCommittee bool         `json:"currently-in-committee"`
+	EPoSStatus           string       `json:"epos-status"`
+	ActiveStatus         string       `json:"active-status"`
+	Lifetime             *Lifetime    `json:"lifetime"`
+}
+
+// Signing counts the blocks a validator signed out of those it had to sign
+type Signing struct {
+	Signed *big.Int `json:"signed"`
+	ToSign *big.Int `json:"to-sign"`
+}
+
+// Performance is the validator's signing in the current epoch
+type Performance struct {
+	Signing struct {
+		Signed *big.Int `json:"current-epoch-signed"`
+		ToSign *big.Int `json:"current-epoch-to-sign"`
+	} `json:"current-epoch-signing-percent"`
+}
+
+// Lifetime sums up the validator since its creation
+type Lifetime struct {
+	Blocks Signing `json:"blocks"`
+}
+
+// Metrics are the validator's statistics for the current epoch
+type Metrics struct {
+	ByBLSKey []KeyMetrics `json:"by-bls-key"`
+}
+
+// KeyMetrics describes the committee slot held by one bls key
+type KeyMetrics struct {
+	Key struct {
+		BLSPublicKey string `json:"bls-public-key"`
+		ShardID      uint32 `json:"shard-id"`
+	} `json:"key"`
+	EffectiveStake string `json:"effective-stake"`
+}
+
+// NormalizeBLSKey lower cases a hex bls public key and drops its 0x prefix,
+// the form the node reports keys in
+func NormalizeBLSKey(key string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(key), "0x"))
+}
+
+// HasKey reports whether key is one of the validator's slot keys
+func (i *Information) HasKey(key string) bool {
+	key = NormalizeBLSKey(key)
+	for _, k := range i.Validator.BLSPublicKeys {
+		if NormalizeBLSKey(k) == key {
+			return true
+		}
+	}
+	return false
+}
+
+// ElectedKeys lists the validator's keys holding a slot in the current
+// committee
+func (i *Information) ElectedKeys() []string {
+	elected := []string{}
+	if i.Metrics == nil {
+		return elected
+	}
+	for _, vote := range i.Metrics.ByBLSKey {
+		elected = append(elected, NormalizeBLSKey(vote.Key.BLSPublicKey))
+	}
+	return elected
+}
+
+// IsElected reports whether key holds a slot in the current committee
+func (i *Information) IsElected(key string) bool {
+	key = NormalizeBLSKey(key)
+	for _, k := range i.ElectedKeys() {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// GetInformation fetches the validator information of address from node
+func GetInformation(node, address string) (*Information, error) {
+	info := &Information{}
+	if err := rpc.RequestResult(rpc.Method.GetValidatorInformation, node, []interface{}{address}, info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// AllInformation fetches the validator information of every validator from
+// node, one page at a time
+func AllInformation(node string) ([]Information, error) {
+	all := []Information{}
+	for page := 0; ; page++ {
+		infos := []Information{}
+		if err := rpc.RequestResult(rpc.Method.GetAllValidatorInformation, node, []interface{}{page}, &infos); err != nil {
+			return nil, err
+		}
+		if len(infos) == 0 {
+			return all, nil
+		}
+		all = append(all, infos...)
+	}
+}
+
+// Header is the part of a block header the sdk acts on
+type Header struct {
+	Number   uint64 `json:"blockNumber"`
+	Epoch    uint64 `json:"epoch"`
+	UnixTime int64  `json:"unixtime"`
+}
+
+// LatestHeader fetches the latest block header of node
+func LatestHeader(node string) (*Header, error) {
+	header := &Header{}
+	if err := rpc.RequestResult(rpc.Method.GetLatestBlockHeader, node, []interface{}{}, header); err != nil {
+		return nil, err
+	}
+	return header, nil
+}
+
+// CurrentEpoch returns the epoch of the latest block header of node
+func CurrentEpoch(node string) (uint64, error) {
+	header, err := LatestHeader(node)
+	if err != nil {
+		return 0, err
+	}
+	return header.Epoch, nil
+}
+
+// ShardForKey returns the shard a bls public key is assigned to on a network
+// of shardCount shards, the serialized key modulo the shard count as the
+// node assigns it
+func ShardForKey(key string, shardCount int) (uint32, error) {
+	if shardCount <= 0 {
+		return 0, fmt.Errorf("invalid shard count %d", shardCount)
+	}
+	publicKey := bls_core.PublicKey{}
+	if err := publicKey.DeserializeHexStr(NormalizeBLSKey(key)); err != nil {
+		return 0, fmt.Errorf("bad bls public key %s: %w", key, err)
+	}
+	shard := new(big.Int).Mod(bls.FromLibBLSPublicKeyUnsafe(&publicKey).Big(), big.NewInt(int64(shardCount)))
+	return uint32(shard.Uint64()), nil
+}
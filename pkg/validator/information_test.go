@@ -0,0 +1,31 @@
+package validator
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetInformationKeepsBigAmounts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		call := map[string]interface{}{}
+		json.NewDecoder(r.Body).Decode(&call)
+		id, _ := json.Marshal(call["id"])
+		w.Write([]byte(`{"jsonrpc":"2.0","id":` + string(id) + `,"result":{` +
+			`"validator":{"address":"one1validator","max-total-delegation":100000000000000000000000000},` +
+			`"total-delegation":12345678901234567890123}}`))
+	}))
+	defer server.Close()
+
+	info, err := GetInformation(server.URL, "one1validator")
+	if err != nil {
+		t.Fatalf("GetInformation returned error %v", err)
+	}
+	if total := info.TotalDelegation.String(); total != "12345678901234567890123" {
+		t.Errorf("GetInformation returned total-delegation %s, expected 12345678901234567890123", total)
+	}
+	if max := info.Validator.MaxTotalDelegation.String(); max != "100000000000000000000000000" {
+		t.Errorf("GetInformation returned max-total-delegation %s, expected 100000000000000000000000000", max)
+	}
+}
@@ -0,0 +1,222 @@
+package validator
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/intelchain-itc/itc-sdk/pkg/common"
+)
+
+// Ranking is what a delegator compares validators by, rates and uptimes are
+// fractions between 0 and 1 and amounts are in atto
+type Ranking struct {
+	Address            string
+	Name               string
+	Uptime             float64
+	EpochUptime        float64
+	Signed             *big.Int
+	ToSign             *big.Int
+	Rate               float64
+	MaxRate            float64
+	MaxChangeRate      float64
+	TotalDelegation    *big.Int
+	MaxTotalDelegation *big.Int
+	Headroom           *big.Int
+	Elected            bool
+	Active             bool
+	EPoSStatus         string
+	EffectiveStake     *big.Int
+}
+
+// RankFilter drops validators a delegator would not pick, zero values do
+// not filter
+type RankFilter struct {
+	MinUptime     float64
+	MaxRate       float64
+	MaxChangeRate float64
+	MinHeadroom   *big.Int
+	ElectedOnly   bool
+	ActiveOnly    bool
+}
+
+// RankSortKeys are the criteria rankings can be sorted by, each in the order
+// a delegator prefers: highest uptime first, lowest rate first and so on
+var RankSortKeys = []string{
+	"uptime", "epoch-uptime", "rate", "max-change-rate", "delegation", "headroom", "effective-stake", "name",
+}
+
+// RankHeader names the columns of Ranking.Row
+var RankHeader = []string{
+	"address", "name", "uptime", "epoch-uptime", "rate", "max-rate", "max-change-rate",
+	"delegation", "max-delegation", "headroom", "elected", "active", "effective-stake",
+}
+
+// NewRanking works out the ranking figures of a validator
+func NewRanking(info *Information) Ranking {
+	r := Ranking{
+		Address:            info.Validator.Address,
+		Name:               info.Validator.Name,
+		Signed:             new(big.Int),
+		ToSign:             new(big.Int),
+		Rate:               parseRate(info.Validator.Rate),
+		MaxRate:            parseRate(info.Validator.MaxRate),
+		MaxChangeRate:      parseRate(info.Validator.MaxChangeRate),
+		TotalDelegation:    new(big.Int),
+		MaxTotalDelegation: new(big.Int),
+		Headroom:           new(big.Int),
+		Elected:            info.CurrentlyInCommittee,
+		Active:             info.ActiveStatus == "active",
+		EPoSStatus:         info.EPoSStatus,
+		EffectiveStake:     new(big.Int),
+	}
+	if info.Lifetime != nil {
+		r.Signed, r.ToSign = orZero(info.Lifetime.Blocks.Signed), orZero(info.Lifetime.Blocks.ToSign)
+		r.Uptime = fraction(r.Signed, r.ToSign)
+	}
+	if info.Performance != nil {
+		r.EpochUptime = fraction(info.Performance.Signing.Signed, info.Performance.Signing.ToSign)
+	}
+	if info.TotalDelegation != nil {
+		r.TotalDelegation.Set(info.TotalDelegation)
+	}
+	if info.Validator.MaxTotalDelegation != nil {
+		r.MaxTotalDelegation.Set(info.Validator.MaxTotalDelegation)
+		r.Headroom.Sub(r.MaxTotalDelegation, r.TotalDelegation)
+		if r.Headroom.Sign() < 0 {
+			r.Headroom.SetInt64(0)
+		}
+	}
+	if info.Metrics != nil {
+		for _, key := range info.Metrics.ByBLSKey {
+			if stake, ok := new(big.Rat).SetString(key.EffectiveStake); ok {
+				r.EffectiveStake.Add(r.EffectiveStake, new(big.Int).Quo(stake.Num(), stake.Denom()))
+			}
+		}
+	}
+	return r
+}
+
+// Rank works out the rankings of validators, keeping those passing filter
+func Rank(infos []Information, filter RankFilter) []Ranking {
+	rankings := []Ranking{}
+	for i := range infos {
+		r := NewRanking(&infos[i])
+		if filter.Keeps(r) {
+			rankings = append(rankings, r)
+		}
+	}
+	return rankings
+}
+
+// Keeps reports whether r passes the filter
+func (f RankFilter) Keeps(r Ranking) bool {
+	switch {
+	case f.MinUptime > 0 && r.Uptime < f.MinUptime:
+		return false
+	case f.MaxRate > 0 && r.Rate > f.MaxRate:
+		return false
+	case f.MaxChangeRate > 0 && r.MaxChangeRate > f.MaxChangeRate:
+		return false
+	case f.MinHeadroom != nil && r.Headroom.Cmp(f.MinHeadroom) < 0:
+		return false
+	case f.ElectedOnly && !r.Elected:
+		return false
+	case f.ActiveOnly && !r.Active:
+		return false
+	}
+	return true
+}
+
+// SortRankings orders rankings best first by one of RankSortKeys, or worst
+// first when reverse is set. Ties keep the order of the address.
+func SortRankings(rankings []Ranking, key string, reverse bool) error {
+	var better func(a, b Ranking) int
+	switch key {
+	case "uptime":
+		better = func(a, b Ranking) int { return compareFloat(a.Uptime, b.Uptime) }
+	case "epoch-uptime":
+		better = func(a, b Ranking) int { return compareFloat(a.EpochUptime, b.EpochUptime) }
+	case "rate":
+		better = func(a, b Ranking) int { return compareFloat(b.Rate, a.Rate) }
+	case "max-change-rate":
+		better = func(a, b Ranking) int { return compareFloat(b.MaxChangeRate, a.MaxChangeRate) }
+	case "delegation":
+		better = func(a, b Ranking) int { return a.TotalDelegation.Cmp(b.TotalDelegation) }
+	case "headroom":
+		better = func(a, b Ranking) int { return a.Headroom.Cmp(b.Headroom) }
+	case "effective-stake":
+		better = func(a, b Ranking) int { return a.EffectiveStake.Cmp(b.EffectiveStake) }
+	case "name":
+		better = func(a, b Ranking) int { return strings.Compare(strings.ToLower(b.Name), strings.ToLower(a.Name)) }
+	default:
+		return fmt.Errorf("unknown sort key %q, expected one of %s", key, strings.Join(RankSortKeys, ", "))
+	}
+	sort.SliceStable(rankings, func(i, j int) bool {
+		c := better(rankings[i], rankings[j])
+		if c == 0 {
+			return rankings[i].Address < rankings[j].Address
+		}
+		return (c > 0) != reverse
+	})
+	return nil
+}
+
+// Row is the ranking as the columns of RankHeader
+func (r Ranking) Row() []string {
+	uptime := "-"
+	if r.ToSign.Sign() > 0 {
+		uptime = formatPercent(r.Uptime)
+	}
+	return []string{
+		r.Address,
+		r.Name,
+		uptime,
+		formatPercent(r.EpochUptime),
+		formatPercent(r.Rate),
+		formatPercent(r.MaxRate),
+		formatPercent(r.MaxChangeRate),
+		common.FormatITC(r.TotalDelegation),
+		common.FormatITC(r.MaxTotalDelegation),
+		common.FormatITC(r.Headroom),
+		strconv.FormatBool(r.Elected),
+		strconv.FormatBool(r.Active),
+		common.FormatITC(r.EffectiveStake),
+	}
+}
+
+func parseRate(rate string) float64 {
+	f, _ := strconv.ParseFloat(rate, 64)
+	return f
+}
+
+func orZero(i *big.Int) *big.Int {
+	if i == nil {
+		return new(big.Int)
+	}
+	return i
+}
+
+func fraction(part, whole *big.Int) float64 {
+	if part == nil || whole == nil || whole.Sign() == 0 {
+		return 0
+	}
+	f, _ := new(big.Rat).SetFrac(part, whole).Float64()
+	return f
+}
+
+func compareFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func formatPercent(f float64) string {
+	return strconv.FormatFloat(f*100, 'f', 2, 64) + "%"
+}
@@ -0,0 +1,123 @@
+package validator
+
+import (
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+const testAllInformation = `[{
+  "validator": {
+    "address": "itc1a", "name": "alice", "rate": "0.10", "max-rate": "0.5", "max-change-rate": "0.05",
+    "max-total-delegation": 1000000000000000000000
+  },
+  "current-epoch-performance": {
+    "current-epoch-signing-percent": {"current-epoch-signed": 90, "current-epoch-to-sign": 100}
+  },
+  "metrics": {"by-bls-key": [
+    {"key": {"bls-public-key": "aa", "shard-id": 0}, "effective-stake": "300000000000000000000.000000000000000000"},
+    {"key": {"bls-public-key": "bb", "shard-id": 1}, "effective-stake": "300000000000000000000.000000000000000000"}
+  ]},
+  "total-delegation": 600000000000000000000,
+  "currently-in-committee": true,
+  "active-status": "active",
+  "lifetime": {"blocks": {"to-sign": 1000, "signed": 990}}
+}, {
+  "validator": {
+    "address": "itc1b", "name": "bob", "rate": "0.05", "max-rate": "0.1", "max-change-rate": "0.01",
+    "max-total-delegation": 1000000000000000000000
+  },
+  "metrics": null,
+  "total-delegation": 1000000000000000000000,
+  "currently-in-committee": false,
+  "active-status": "inactive",
+  "lifetime": {"blocks": {"to-sign": 1000, "signed": 800}}
+}, {
+  "validator": {
+    "address": "itc1c", "name": "carol", "rate": "0.20", "max-rate": "1", "max-change-rate": "0.2"
+  },
+  "total-delegation": 100000000000000000000,
+  "currently-in-committee": true,
+  "active-status": "active",
+  "lifetime": {"blocks": {"to-sign": 0, "signed": 0}}
+}]`
+
+func testRankings(t *testing.T, filter RankFilter) []Ranking {
+	infos := []Information{}
+	if err := json.Unmarshal([]byte(testAllInformation), &infos); err != nil {
+		t.Fatal(err)
+	}
+	return Rank(infos, filter)
+}
+
+func addresses(rankings []Ranking) []string {
+	out := []string{}
+	for _, r := range rankings {
+		out = append(out, r.Address)
+	}
+	return out
+}
+
+func TestNewRanking(t *testing.T) {
+	rankings := testRankings(t, RankFilter{})
+	expected := []string{
+		"itc1a", "alice", "99.00%", "90.00%", "10.00%", "50.00%", "5.00%",
+		"600", "1000", "400", "true", "true", "600",
+	}
+	if row := rankings[0].Row(); !reflect.DeepEqual(row, expected) {
+		t.Errorf("Row() returned %v, expected %v", row, expected)
+	}
+	if row := rankings[2].Row(); row[2] != "-" || row[9] != "0" {
+		t.Errorf("Row() returned %v, expected no uptime and no headroom", row)
+	}
+}
+
+func TestRankFilter(t *testing.T) {
+	tests := []struct {
+		filter   RankFilter
+		expected []string
+	}{
+		{RankFilter{}, []string{"itc1a", "itc1b", "itc1c"}},
+		{RankFilter{MinUptime: 0.9}, []string{"itc1a"}},
+		{RankFilter{MaxRate: 0.1}, []string{"itc1a", "itc1b"}},
+		{RankFilter{MaxChangeRate: 0.05}, []string{"itc1a", "itc1b"}},
+		{RankFilter{MinHeadroom: big.NewInt(1)}, []string{"itc1a"}},
+		{RankFilter{ElectedOnly: true}, []string{"itc1a", "itc1c"}},
+		{RankFilter{ActiveOnly: true, MaxRate: 0.15}, []string{"itc1a"}},
+	}
+	for _, test := range tests {
+		if result := addresses(testRankings(t, test.filter)); !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("Rank(%+v) returned %v, expected %v", test.filter, result, test.expected)
+		}
+	}
+}
+
+func TestSortRankings(t *testing.T) {
+	tests := []struct {
+		key      string
+		reverse  bool
+		expected []string
+	}{
+		{"uptime", false, []string{"itc1a", "itc1b", "itc1c"}},
+		{"uptime", true, []string{"itc1c", "itc1b", "itc1a"}},
+		{"rate", false, []string{"itc1b", "itc1a", "itc1c"}},
+		{"max-change-rate", false, []string{"itc1b", "itc1a", "itc1c"}},
+		{"delegation", false, []string{"itc1b", "itc1a", "itc1c"}},
+		{"headroom", false, []string{"itc1a", "itc1b", "itc1c"}},
+		{"effective-stake", false, []string{"itc1a", "itc1b", "itc1c"}},
+		{"name", false, []string{"itc1a", "itc1b", "itc1c"}},
+	}
+	for _, test := range tests {
+		rankings := testRankings(t, RankFilter{})
+		if err := SortRankings(rankings, test.key, test.reverse); err != nil {
+			t.Fatalf("SortRankings(%s) failed %v", test.key, err)
+		}
+		if result := addresses(rankings); !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("SortRankings(%s, %v) returned %v, expected %v", test.key, test.reverse, result, test.expected)
+		}
+	}
+	if err := SortRankings(nil, "apr", false); err == nil {
+		t.Errorf("SortRankings(apr) returned no error, expected an unknown key error")
+	}
+}
@@ -0,0 +1,163 @@
+package validator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// RotationStage is how far a bls key rotation has come
+type RotationStage string
+
+const (
+	// RotationPending is a rotation that has not sent anything yet
+	RotationPending RotationStage = "pending"
+	// RotationKeyAdded is waiting for the new key to be elected
+	RotationKeyAdded RotationStage = "key-added"
+	// RotationElected has the new key elected and the old key still in place
+	RotationElected RotationStage = "elected"
+	// RotationDone has the old key removed
+	RotationDone RotationStage = "done"
+)
+
+var (
+	ErrRotationDeadline = errors.New("new bls key was not elected before the deadline")
+	ErrRotationMismatch = errors.New("saved rotation is for different keys")
+)
+
+// Rotation is the persisted progress of replacing one slot key of a
+// validator with another, so an interrupted rotation can be resumed
+type Rotation struct {
+	Validator    string        `json:"validator"`
+	OldKey       string        `json:"old-bls-key"`
+	NewKey       string        `json:"new-bls-key"`
+	ShardID      uint32        `json:"shard-id"`
+	Deadline     time.Time     `json:"deadline"`
+	Stage        RotationStage `json:"stage"`
+	AddTx        string        `json:"add-tx,omitempty"`
+	AddedEpoch   uint64        `json:"added-epoch,omitempty"`
+	ElectedEpoch uint64        `json:"elected-epoch,omitempty"`
+	RemoveTx     string        `json:"remove-tx,omitempty"`
+	UpdatedAt    time.Time     `json:"updated-at"`
+}
+
+// RotationChain is what a rotation needs from the network, AddKey and
+// RemoveKey send an edit-validator transaction and return its hash
+type RotationChain interface {
+	Information() (*Information, error)
+	Epoch() (uint64, error)
+	AddKey(key string) (string, error)
+	RemoveKey(key string) (string, error)
+}
+
+// NewRotation plans replacing oldKey of validator with newKey, which maps to
+// shardID, giving the new key until deadline to be elected
+func NewRotation(validator, oldKey, newKey string, shardID uint32, deadline time.Time) *Rotation {
+	return &Rotation{
+		Validator: validator,
+		OldKey:    NormalizeBLSKey(oldKey),
+		NewKey:    NormalizeBLSKey(newKey),
+		ShardID:   shardID,
+		Deadline:  deadline,
+		Stage:     RotationPending,
+	}
+}
+
+// LoadRotation reads a rotation saved at path
+func LoadRotation(path string) (*Rotation, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	r := &Rotation{}
+	if err := json.Unmarshal(data, r); err != nil {
+		return nil, fmt.Errorf("could not read rotation %s: %w", path, err)
+	}
+	return r, nil
+}
+
+// Save writes the rotation to path through a temporary file, so a crash
+// leaves either the previous or the new state behind
+func (r *Rotation) Save(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Matches reports whether the rotation replaces oldKey of validator with newKey
+func (r *Rotation) Matches(validator, oldKey, newKey string) bool {
+	return r.Validator == validator &&
+		r.OldKey == NormalizeBLSKey(oldKey) && r.NewKey == NormalizeBLSKey(newKey)
+}
+
+// Step advances the rotation by at most one stage, leaving the stage as is
+// while the new key waits for an election. Each stage first looks at the
+// chain, so a transaction that went through before the state was saved is
+// not sent again.
+func (r *Rotation) Step(chain RotationChain, now time.Time) error {
+	if r.Stage == RotationDone {
+		return nil
+	}
+	info, err := chain.Information()
+	if err != nil {
+		return err
+	}
+	switch r.Stage {
+	case RotationPending:
+		if !info.HasKey(r.NewKey) {
+			if !info.HasKey(r.OldKey) {
+				return fmt.Errorf("validator %s has no bls key %s", r.Validator, r.OldKey)
+			}
+			txHash, err := chain.AddKey(r.NewKey)
+			if err != nil {
+				return err
+			}
+			r.AddTx = txHash
+		}
+		if r.AddedEpoch, err = chain.Epoch(); err != nil {
+			return err
+		}
+		r.Stage = RotationKeyAdded
+	case RotationKeyAdded:
+		if !info.HasKey(r.NewKey) {
+			return fmt.Errorf("bls key %s is not on validator %s, was the add transaction rejected?", r.NewKey, r.Validator)
+		}
+		if !info.IsElected(r.NewKey) {
+			if !r.Deadline.IsZero() && now.After(r.Deadline) {
+				return fmt.Errorf("%w: added in epoch %d, deadline %s",
+					ErrRotationDeadline, r.AddedEpoch, r.Deadline.Format(time.RFC3339))
+			}
+			return nil
+		}
+		if r.ElectedEpoch, err = chain.Epoch(); err != nil {
+			return err
+		}
+		r.Stage = RotationElected
+	case RotationElected:
+		if info.HasKey(r.OldKey) {
+			txHash, err := chain.RemoveKey(r.OldKey)
+			if err != nil {
+				return err
+			}
+			r.RemoveTx = txHash
+		}
+		r.Stage = RotationDone
+	default:
+		return fmt.Errorf("unknown rotation stage %q", r.Stage)
+	}
+	r.UpdatedAt = now
+	return nil
+}
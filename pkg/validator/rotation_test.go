@@ -0,0 +1,164 @@
+package validator
+
+import (
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/intelchain-itc/intelchain/crypto/bls"
+)
+
+const (
+	testOldKey = "aa"
+	testNewKey = "bb"
+)
+
+// fakeChain applies key edits right away and elects the keys in elected
+type fakeChain struct {
+	keys    []string
+	elected []string
+	epoch   uint64
+	sent    []string
+}
+
+func (c *fakeChain) Information() (*Information, error) {
+	info := &Information{}
+	info.Validator.BLSPublicKeys = append([]string{}, c.keys...)
+	info.Metrics = &Metrics{}
+	for _, k := range c.elected {
+		vote := KeyMetrics{}
+		vote.Key.BLSPublicKey = k
+		info.Metrics.ByBLSKey = append(info.Metrics.ByBLSKey, vote)
+	}
+	return info, nil
+}
+
+func (c *fakeChain) Epoch() (uint64, error) {
+	return c.epoch, nil
+}
+
+func (c *fakeChain) AddKey(key string) (string, error) {
+	c.keys = append(c.keys, key)
+	c.sent = append(c.sent, "add "+key)
+	return "0xadd", nil
+}
+
+func (c *fakeChain) RemoveKey(key string) (string, error) {
+	keys := []string{}
+	for _, k := range c.keys {
+		if k != key {
+			keys = append(keys, k)
+		}
+	}
+	c.keys = keys
+	c.sent = append(c.sent, "remove "+key)
+	return "0xremove", nil
+}
+
+func TestRotationStages(t *testing.T) {
+	now := time.Unix(1000, 0)
+	chain := &fakeChain{keys: []string{testOldKey}, elected: []string{testOldKey}, epoch: 7}
+	r := NewRotation("itc1validator", "0x"+testOldKey, testNewKey, 0, now.Add(time.Hour))
+
+	expected := []RotationStage{RotationKeyAdded, RotationKeyAdded, RotationElected, RotationDone, RotationDone}
+	for i, stage := range expected {
+		if i == 2 {
+			chain.elected = append(chain.elected, testNewKey)
+			chain.epoch = 8
+		}
+		if err := r.Step(chain, now); err != nil {
+			t.Fatalf("Step #%d failed %v", i+1, err)
+		}
+		if r.Stage != stage {
+			t.Errorf("Step #%d left stage %s, expected %s", i+1, r.Stage, stage)
+		}
+	}
+	if len(chain.sent) != 2 || chain.sent[0] != "add "+testNewKey || chain.sent[1] != "remove "+testOldKey {
+		t.Errorf("rotation sent %v, expected one add and one remove", chain.sent)
+	}
+	if r.AddedEpoch != 7 || r.ElectedEpoch != 8 {
+		t.Errorf("rotation recorded epochs %d and %d, expected 7 and 8", r.AddedEpoch, r.ElectedEpoch)
+	}
+}
+
+func TestRotationDoesNotResendAfterCrash(t *testing.T) {
+	// the add went through but the state was not saved
+	chain := &fakeChain{keys: []string{testOldKey, testNewKey}}
+	r := NewRotation("itc1validator", testOldKey, testNewKey, 0, time.Time{})
+	if err := r.Step(chain, time.Now()); err != nil {
+		t.Fatalf("Step failed %v", err)
+	}
+	if r.Stage != RotationKeyAdded || len(chain.sent) != 0 {
+		t.Errorf("Step left stage %s and sent %v, expected %s and nothing sent", r.Stage, chain.sent, RotationKeyAdded)
+	}
+}
+
+func TestRotationDeadline(t *testing.T) {
+	now := time.Unix(1000, 0)
+	chain := &fakeChain{keys: []string{testOldKey, testNewKey}}
+	r := NewRotation("itc1validator", testOldKey, testNewKey, 0, now.Add(-time.Second))
+	r.Stage = RotationKeyAdded
+	if err := r.Step(chain, now); !errors.Is(err, ErrRotationDeadline) {
+		t.Errorf("Step past the deadline returned %v, expected %v", err, ErrRotationDeadline)
+	}
+	if r.Stage != RotationKeyAdded || len(chain.sent) != 0 {
+		t.Errorf("Step past the deadline left stage %s and sent %v", r.Stage, chain.sent)
+	}
+}
+
+func TestRotationMissingOldKey(t *testing.T) {
+	chain := &fakeChain{keys: []string{"cc"}}
+	r := NewRotation("itc1validator", testOldKey, testNewKey, 0, time.Time{})
+	if err := r.Step(chain, time.Now()); err == nil {
+		t.Errorf("Step without the old key succeeded, expected an error")
+	}
+	if len(chain.sent) != 0 {
+		t.Errorf("Step without the old key sent %v", chain.sent)
+	}
+}
+
+func TestRotationSaveAndLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rotation")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "nested", "rotation.json")
+
+	r := NewRotation("itc1validator", testOldKey, testNewKey, 1, time.Unix(2000, 0).UTC())
+	r.Stage = RotationElected
+	r.AddTx = "0xadd"
+	if err := r.Save(path); err != nil {
+		t.Fatalf("Save failed %v", err)
+	}
+	loaded, err := LoadRotation(path)
+	if err != nil {
+		t.Fatalf("LoadRotation failed %v", err)
+	}
+	if *loaded != *r {
+		t.Errorf("LoadRotation returned %+v, expected %+v", loaded, r)
+	}
+	if !loaded.Matches("itc1validator", "0x"+testOldKey, testNewKey) || loaded.Matches("itc1validator", testNewKey, testOldKey) {
+		t.Errorf("Matches does not compare the validator and keys")
+	}
+}
+
+func TestShardForKey(t *testing.T) {
+	key := bls.RandPrivateKey().GetPublicKey().SerializeToHexStr()
+	raw, _ := hex.DecodeString(key)
+	for _, shardCount := range []int{1, 2, 4, 5} {
+		expected := uint32(new(big.Int).Mod(new(big.Int).SetBytes(raw), big.NewInt(int64(shardCount))).Uint64())
+		shard, err := ShardForKey("0x"+key, shardCount)
+		if err != nil || shard != expected {
+			t.Errorf("ShardForKey(%s, %d) returned %d %v, expected %d", key, shardCount, shard, err, expected)
+		}
+	}
+	if _, err := ShardForKey("0x05", 4); err == nil {
+		t.Errorf("ShardForKey of a short key succeeded, expected an error")
+	}
+}
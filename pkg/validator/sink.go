@@ -0,0 +1,86 @@
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"strconv"
+	"time"
+)
+
+// Sink delivers alerts somewhere a person will see them
+type Sink interface {
+	Send(alert Alert) error
+}
+
+// WriterSink writes each alert as a line of JSON
+type WriterSink struct {
+	W io.Writer
+}
+
+// Send writes the alert
+func (s WriterSink) Send(alert Alert) error {
+	data, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(s.W, string(data))
+	return err
+}
+
+// ExecSink runs a shell command per alert, with the alert as JSON on its
+// standard input and its kind, message and resolved flag in ITC_ALERT_*
+// environment variables
+type ExecSink struct {
+	Command string
+}
+
+// Send runs the command and waits for it
+func (s ExecSink) Send(alert Alert) error {
+	data, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command("sh", "-c", s.Command)
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Env = append(os.Environ(),
+		"ITC_ALERT_KIND="+alert.Kind,
+		"ITC_ALERT_VALIDATOR="+alert.Validator,
+		"ITC_ALERT_MESSAGE="+alert.Message,
+		"ITC_ALERT_RESOLVED="+strconv.FormatBool(alert.Resolved),
+	)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("alert hook failed: %w: %s", err, bytes.TrimSpace(out))
+	}
+	return nil
+}
+
+// WebhookSink posts each alert as JSON to a URL
+type WebhookSink struct {
+	URL     string
+	Timeout time.Duration
+}
+
+// Send posts the alert, any status other than 2xx is an error
+func (s WebhookSink) Send(alert Alert) error {
+	data, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+	client := &http.Client{Timeout: s.Timeout}
+	resp, err := client.Post(s.URL, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("could not post alert: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("alert webhook answered %s: %s", resp.Status, bytes.TrimSpace(body))
+	}
+	return nil
+}
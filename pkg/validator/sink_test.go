@@ -0,0 +1,75 @@
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testAlert = Alert{Kind: AlertLowSigning, Validator: "itc1validator", Message: "signed 80 of 100 blocks", Epoch: 7}
+
+func TestWriterSink(t *testing.T) {
+	out := &bytes.Buffer{}
+	if err := (WriterSink{out}).Send(testAlert); err != nil {
+		t.Fatalf("Send failed %v", err)
+	}
+	received := Alert{}
+	if err := json.Unmarshal(out.Bytes(), &received); err != nil || received != testAlert {
+		t.Errorf("WriterSink wrote %s, expected %+v", out.String(), testAlert)
+	}
+}
+
+func TestExecSink(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sink")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "alert")
+	if err := (ExecSink{`echo "$ITC_ALERT_KIND" > ` + path + ` && cat >> ` + path}).Send(testAlert); err != nil {
+		t.Fatalf("Send failed %v", err)
+	}
+	data, _ := ioutil.ReadFile(path)
+	if !strings.HasPrefix(string(data), AlertLowSigning+"\n{") {
+		t.Errorf("ExecSink hook saw %q, expected the kind then the alert", data)
+	}
+	if err := (ExecSink{"echo broken >&2; exit 3"}).Send(testAlert); err == nil || !strings.Contains(err.Error(), "broken") {
+		t.Errorf("ExecSink of a failing hook returned %v, expected its output", err)
+	}
+}
+
+func TestWebhookSink(t *testing.T) {
+	received := []Alert{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		alert := Alert{}
+		if err := json.NewDecoder(r.Body).Decode(&alert); err != nil || r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received = append(received, alert)
+		if alert.Resolved {
+			http.Error(w, "no resolved alerts", http.StatusTeapot)
+		}
+	}))
+	defer server.Close()
+
+	sink := WebhookSink{URL: server.URL, Timeout: time.Second}
+	if err := sink.Send(testAlert); err != nil {
+		t.Fatalf("Send failed %v", err)
+	}
+	resolved := testAlert
+	resolved.Resolved = true
+	if err := sink.Send(resolved); err == nil || !strings.Contains(err.Error(), "no resolved alerts") {
+		t.Errorf("Send to a rejecting webhook returned %v, expected its answer", err)
+	}
+	if len(received) != 2 || received[0] != testAlert {
+		t.Errorf("webhook received %+v, expected %+v first", received, testAlert)
+	}
+}
@@ -0,0 +1,214 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"strconv"
+
+	"github.com/intelchain-itc/itc-sdk/pkg/common"
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	ErrSpecImmutable       = errors.New("can not be changed after the validator is created")
+	ErrSpecTooManyKeyEdits = errors.New("an edit can add and remove at most one bls key")
+)
+
+// Spec declares how a validator should look. It is read from YAML or JSON,
+// fields left out are not part of the declaration and are never changed.
+type Spec struct {
+	Address     string          `yaml:"validator-addr" json:"validator-addr"`
+	Description SpecDescription `yaml:"description" json:"description"`
+	Commission  SpecCommission  `yaml:"commission" json:"commission"`
+	Delegation  SpecDelegation  `yaml:"delegation" json:"delegation"`
+	// BLSKeyFiles are encrypted bls key files, each with its passphrase in
+	// a .pass file next to it
+	BLSKeyFiles []string `yaml:"bls-key-files" json:"bls-key-files"`
+	Active      *bool    `yaml:"active" json:"active"`
+}
+
+// SpecDescription is the description of a validator
+type SpecDescription struct {
+	Name            string `yaml:"name" json:"name"`
+	Identity        string `yaml:"identity" json:"identity"`
+	Website         string `yaml:"website" json:"website"`
+	SecurityContact string `yaml:"security-contact" json:"security-contact"`
+	Details         string `yaml:"details" json:"details"`
+}
+
+// SpecCommission holds the commission rates as decimal fractions
+type SpecCommission struct {
+	Rate          string `yaml:"rate" json:"rate"`
+	MaxRate       string `yaml:"max-rate" json:"max-rate"`
+	MaxChangeRate string `yaml:"max-change-rate" json:"max-change-rate"`
+}
+
+// SpecDelegation holds the delegation limits and the initial self
+// delegation, all in ITC
+type SpecDelegation struct {
+	MinSelfDelegation  string `yaml:"min-self-delegation" json:"min-self-delegation"`
+	MaxTotalDelegation string `yaml:"max-total-delegation" json:"max-total-delegation"`
+	Amount             string `yaml:"amount" json:"amount"`
+}
+
+// Change is one field an edit of a validator changes, named after the
+// edit-validator flag setting it
+type Change struct {
+	Field   string `json:"field"`
+	Current string `json:"current"`
+	Desired string `json:"desired"`
+}
+
+// LoadSpec reads a validator spec from a YAML or JSON file, rejecting
+// unknown fields so a typo is not silently ignored
+func LoadSpec(path string) (*Spec, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	spec := &Spec{}
+	if err := yaml.UnmarshalStrict(data, spec); err != nil {
+		return nil, fmt.Errorf("could not read validator spec %s: %w", path, err)
+	}
+	return spec, nil
+}
+
+// Diff lists what an edit has to change to turn the validator described by
+// info into the spec, whose bls key files hold keys. A nil keys leaves the
+// keys of the validator as they are.
+func (s *Spec) Diff(info *Information, keys []string) ([]Change, error) {
+	changes := []Change{}
+	current := info.Validator
+	for _, field := range []struct{ name, current, desired string }{
+		{"name", current.Name, s.Description.Name},
+		{"identity", current.Identity, s.Description.Identity},
+		{"website", current.Website, s.Description.Website},
+		{"security-contact", current.SecurityContact, s.Description.SecurityContact},
+		{"details", current.Details, s.Description.Details},
+	} {
+		if field.desired != "" && field.desired != field.current {
+			changes = append(changes, Change{field.name, field.current, field.desired})
+		}
+	}
+
+	for _, field := range []struct{ name, current, desired string }{
+		{"max-rate", current.MaxRate, s.Commission.MaxRate},
+		{"max-change-rate", current.MaxChangeRate, s.Commission.MaxChangeRate},
+	} {
+		same, err := sameDecimal(field.current, field.desired)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", field.name, err)
+		}
+		if !same {
+			return nil, fmt.Errorf("%s %w, it is %s on chain", field.name, ErrSpecImmutable, field.current)
+		}
+	}
+	same, err := sameDecimal(current.Rate, s.Commission.Rate)
+	if err != nil {
+		return nil, fmt.Errorf("rate: %w", err)
+	}
+	if !same {
+		changes = append(changes, Change{"rate", current.Rate, s.Commission.Rate})
+	}
+
+	for _, field := range []struct {
+		name    string
+		current *big.Int
+		desired string
+	}{
+		{"min-self-delegation", current.MinSelfDelegation, s.Delegation.MinSelfDelegation},
+		{"max-total-delegation", current.MaxTotalDelegation, s.Delegation.MaxTotalDelegation},
+	} {
+		same, err := sameITC(field.current, field.desired)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", field.name, err)
+		}
+		if !same {
+			changes = append(changes, Change{field.name, common.FormatITC(field.current), field.desired})
+		}
+	}
+
+	if keys != nil {
+		toAdd, toRemove := keyChanges(current.BLSPublicKeys, keys)
+		if len(toAdd) > 1 || len(toRemove) > 1 {
+			return nil, fmt.Errorf(
+				"%w, the spec adds %d and removes %d", ErrSpecTooManyKeyEdits, len(toAdd), len(toRemove),
+			)
+		}
+		for _, key := range toAdd {
+			changes = append(changes, Change{"add-bls-key", "", key})
+		}
+		for _, key := range toRemove {
+			changes = append(changes, Change{"remove-bls-key", key, ""})
+		}
+	}
+
+	if s.Active != nil {
+		isActive := info.ActiveStatus == "active"
+		if *s.Active != isActive {
+			changes = append(changes, Change{
+				"active", strconv.FormatBool(isActive), strconv.FormatBool(*s.Active),
+			})
+		}
+	}
+	return changes, nil
+}
+
+// keyChanges lists the desired keys missing from current and the current
+// keys that are not desired
+func keyChanges(current, desired []string) ([]string, []string) {
+	inCurrent, inDesired := map[string]bool{}, map[string]bool{}
+	for _, key := range current {
+		inCurrent[NormalizeBLSKey(key)] = true
+	}
+	for _, key := range desired {
+		inDesired[NormalizeBLSKey(key)] = true
+	}
+	toAdd, toRemove := []string{}, []string{}
+	for _, key := range desired {
+		if !inCurrent[NormalizeBLSKey(key)] {
+			toAdd = append(toAdd, NormalizeBLSKey(key))
+		}
+	}
+	for _, key := range current {
+		if !inDesired[NormalizeBLSKey(key)] {
+			toRemove = append(toRemove, NormalizeBLSKey(key))
+		}
+	}
+	return toAdd, toRemove
+}
+
+// sameDecimal compares the decimal on chain with the one in the spec, an
+// empty spec value always matches
+func sameDecimal(current, desired string) (bool, error) {
+	if desired == "" {
+		return true, nil
+	}
+	d, ok := new(big.Rat).SetString(desired)
+	if !ok {
+		return false, fmt.Errorf("%q is not a decimal", desired)
+	}
+	c, ok := new(big.Rat).SetString(current)
+	if !ok {
+		return false, nil
+	}
+	return c.Cmp(d) == 0, nil
+}
+
+// sameITC compares an amount in atto on chain with an amount of ITC in the
+// spec, an empty spec value always matches
+func sameITC(current *big.Int, desired string) (bool, error) {
+	if desired == "" {
+		return true, nil
+	}
+	d, ok := new(big.Rat).SetString(desired)
+	if !ok {
+		return false, fmt.Errorf("%q is not an amount", desired)
+	}
+	if current == nil {
+		return false, nil
+	}
+	return new(big.Rat).SetInt(current).Cmp(d.Mul(d, new(big.Rat).SetInt(common.AttoPerITC))) == 0, nil
+}
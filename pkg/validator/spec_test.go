@@ -0,0 +1,139 @@
+package validator
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testInformation = `{
+  "validator": {
+    "address": "itc1validator",
+    "bls-public-keys": ["aa", "bb"],
+    "name": "alice",
+    "identity": "alice-id",
+    "website": "alice.org",
+    "security-contact": "sec",
+    "details": "first",
+    "rate": "0.100000000000000000",
+    "max-rate": "0.900000000000000000",
+    "max-change-rate": "0.050000000000000000",
+    "min-self-delegation": 10000000000000000000000,
+    "max-total-delegation": 500000000000000000000000
+  },
+  "active-status": "active"
+}`
+
+const testSpec = `
+validator-addr: itc1validator
+description:
+  name: alice
+  details: second
+commission:
+  rate: "0.15"
+  max-rate: "0.9"
+delegation:
+  min-self-delegation: "10000"
+  max-total-delegation: "600000.5"
+bls-key-files:
+  - keys/bb.key
+  - keys/cc.key
+active: false
+`
+
+func testInfo(t *testing.T) *Information {
+	info := &Information{}
+	if err := json.Unmarshal([]byte(testInformation), info); err != nil {
+		t.Fatal(err)
+	}
+	return info
+}
+
+func writeSpec(t *testing.T, content string) (string, func()) {
+	dir, err := ioutil.TempDir("", "spec")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "validator.yaml")
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path, func() { os.RemoveAll(dir) }
+}
+
+func TestSpecDiff(t *testing.T) {
+	path, cleanup := writeSpec(t, testSpec)
+	defer cleanup()
+	spec, err := LoadSpec(path)
+	if err != nil {
+		t.Fatalf("LoadSpec failed %v", err)
+	}
+	changes, err := spec.Diff(testInfo(t), []string{"bb", "0xCC"})
+	if err != nil {
+		t.Fatalf("Diff failed %v", err)
+	}
+	expected := []Change{
+		{"details", "first", "second"},
+		{"rate", "0.100000000000000000", "0.15"},
+		{"max-total-delegation", "500000", "600000.5"},
+		{"add-bls-key", "", "cc"},
+		{"remove-bls-key", "aa", ""},
+		{"active", "true", "false"},
+	}
+	if len(changes) != len(expected) {
+		t.Fatalf("Diff returned %v, expected %v", changes, expected)
+	}
+	for i := range changes {
+		if changes[i] != expected[i] {
+			t.Errorf("Diff change #%d is %v, expected %v", i+1, changes[i], expected[i])
+		}
+	}
+}
+
+func TestSpecDiffRejects(t *testing.T) {
+	tests := []struct {
+		spec     string
+		keys     []string
+		expected error
+	}{
+		{"commission:\n  max-rate: \"0.8\"\n", nil, ErrSpecImmutable},
+		{"commission:\n  max-change-rate: \"0.1\"\n", nil, ErrSpecImmutable},
+		{"", []string{"cc", "dd"}, ErrSpecTooManyKeyEdits},
+		{"", []string{}, ErrSpecTooManyKeyEdits},
+	}
+	for _, test := range tests {
+		path, cleanup := writeSpec(t, test.spec)
+		spec, err := LoadSpec(path)
+		cleanup()
+		if err != nil {
+			t.Errorf("LoadSpec(%q) failed %v", test.spec, err)
+			continue
+		}
+		if _, err := spec.Diff(testInfo(t), test.keys); !errors.Is(err, test.expected) {
+			t.Errorf("Diff(%q, %v) returned %v, expected %v", test.spec, test.keys, err, test.expected)
+		}
+	}
+}
+
+func TestLoadSpecRejectsUnknownFields(t *testing.T) {
+	path, cleanup := writeSpec(t, "description:\n  nmae: typo\n")
+	defer cleanup()
+	if _, err := LoadSpec(path); err == nil {
+		t.Errorf("LoadSpec with an unknown field succeeded, expected an error")
+	}
+}
+
+func TestLoadSpecReadsJSON(t *testing.T) {
+	path, cleanup := writeSpec(t, `{"validator-addr": "itc1validator", "commission": {"rate": "0.1"}}`)
+	defer cleanup()
+	spec, err := LoadSpec(path)
+	if err != nil {
+		t.Fatalf("LoadSpec failed %v", err)
+	}
+	if spec.Address != "itc1validator" || spec.Commission.Rate != "0.1" {
+		t.Errorf("LoadSpec returned %+v", spec)
+	}
+}
@@ -0,0 +1,249 @@
+package validator
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/intelchain-itc/itc-sdk/pkg/common"
+	"github.com/intelchain-itc/itc-sdk/pkg/rpc"
+)
+
+const (
+	// AlertNotElected fires when the validator is out of the committee
+	AlertNotElected = "not-elected"
+	// AlertLowSigning fires when the signing rate of the epoch is too low
+	AlertLowSigning = "low-signing"
+	// AlertMissingKeys fires when slot keys of an elected validator are not
+	// in the committee
+	AlertMissingKeys = "missing-keys"
+	// AlertCommissionChanged fires when the commission rate changes
+	AlertCommissionChanged = "commission-changed"
+	// AlertDelegationFull fires when the total delegation nears the max
+	AlertDelegationFull = "delegation-full"
+	// AlertStakingFailure fires for every new entry of the node's staking
+	// error sink for a staking transaction of the validator
+	AlertStakingFailure = "staking-failure"
+
+	// stakingHistoryPageSize is how many of its most recent staking
+	// transactions error sink entries are matched against
+	stakingHistoryPageSize = 100
+)
+
+// Alert is one finding of a validator watch. Conditions such as low signing
+// fire once when they start and again, resolved, when they clear.
+type Alert struct {
+	Kind      string    `json:"kind"`
+	Validator string    `json:"validator"`
+	Message   string    `json:"message"`
+	Resolved  bool      `json:"resolved"`
+	Epoch     uint64    `json:"epoch"`
+	Time      time.Time `json:"time"`
+}
+
+// StakingFailure is an entry of the node's staking error sink
+type StakingFailure struct {
+	TxHash    string `json:"tx-hash-id"`
+	Directive string `json:"directive-kind"`
+	Time      int64  `json:"time-at-rejection"`
+	Message   string `json:"error-message"`
+}
+
+// Observation is what one poll of the node saw of a validator
+type Observation struct {
+	Epoch    uint64
+	Time     time.Time
+	Info     *Information
+	Elected  bool
+	Failures []StakingFailure
+}
+
+// WatchOptions are the thresholds of a watch, fractions between 0 and 1
+type WatchOptions struct {
+	// MinSigning is the lowest signing rate of the epoch not alerted on
+	MinSigning float64
+	// DelegationFull is the share of max-total-delegation alerted on
+	DelegationFull float64
+}
+
+// Watcher turns successive observations of a validator into alerts
+type Watcher struct {
+	validator    string
+	opts         WatchOptions
+	active       map[string]string
+	rate         string
+	seenFailures map[string]bool
+	started      bool
+}
+
+// NewWatcher watches the validator at address
+func NewWatcher(address string, opts WatchOptions) *Watcher {
+	return &Watcher{
+		validator:    address,
+		opts:         opts,
+		active:       map[string]string{},
+		seenFailures: map[string]bool{},
+	}
+}
+
+// Check compares an observation with the earlier ones and returns the
+// alerts it raises. Staking failures already in the sink at the first check
+// are taken as history and not alerted on.
+func (w *Watcher) Check(obs Observation) []Alert {
+	alerts := []Alert{}
+	alert := func(kind, message string, resolved bool) {
+		alerts = append(alerts, Alert{kind, w.validator, message, resolved, obs.Epoch, obs.Time})
+	}
+
+	conditions := w.conditions(obs)
+	kinds := []string{}
+	for kind := range w.active {
+		kinds = append(kinds, kind)
+	}
+	for kind := range conditions {
+		if _, ok := w.active[kind]; !ok {
+			kinds = append(kinds, kind)
+		}
+	}
+	sort.Strings(kinds)
+	for _, kind := range kinds {
+		message, now := conditions[kind]
+		before, was := w.active[kind]
+		switch {
+		case now && (!was || message != before):
+			alert(kind, message, false)
+		case was && !now:
+			alert(kind, before, true)
+		}
+	}
+	w.active = conditions
+
+	if obs.Info != nil {
+		rate := obs.Info.Validator.Rate
+		if w.started && rate != w.rate {
+			alert(AlertCommissionChanged, fmt.Sprintf(
+				"commission rate changed from %s to %s", formatRate(w.rate), formatRate(rate),
+			), false)
+		}
+		w.rate = rate
+	}
+	for _, failure := range obs.Failures {
+		if w.seenFailures[failure.TxHash] {
+			continue
+		}
+		w.seenFailures[failure.TxHash] = true
+		if w.started {
+			alert(AlertStakingFailure, fmt.Sprintf(
+				"%s transaction %s was rejected: %s", failure.Directive, failure.TxHash, failure.Message,
+			), false)
+		}
+	}
+	w.started = true
+	return alerts
+}
+
+// conditions are the problems the observation shows right now, by kind
+func (w *Watcher) conditions(obs Observation) map[string]string {
+	conditions := map[string]string{}
+	if obs.Info == nil {
+		return conditions
+	}
+	info := obs.Info
+	if !obs.Elected {
+		conditions[AlertNotElected] = fmt.Sprintf("not elected, epos status %s", info.EPoSStatus)
+	} else {
+		elected := map[string]bool{}
+		for _, k := range info.ElectedKeys() {
+			elected[k] = true
+		}
+		missing := []string{}
+		for _, k := range info.Validator.BLSPublicKeys {
+			if !elected[NormalizeBLSKey(k)] {
+				missing = append(missing, NormalizeBLSKey(k))
+			}
+		}
+		if len(missing) > 0 {
+			conditions[AlertMissingKeys] = "bls keys not in the committee: " + strings.Join(missing, ", ")
+		}
+	}
+	if info.Performance != nil && w.opts.MinSigning > 0 {
+		signing := info.Performance.Signing
+		if signing.ToSign != nil && signing.ToSign.Sign() > 0 {
+			if rate := fraction(signing.Signed, signing.ToSign); rate < w.opts.MinSigning {
+				conditions[AlertLowSigning] = fmt.Sprintf(
+					"signed %s of %s blocks this epoch, below %s",
+					orZero(signing.Signed), signing.ToSign, formatPercent(w.opts.MinSigning),
+				)
+			}
+		}
+	}
+	maxTotal := info.Validator.MaxTotalDelegation
+	if w.opts.DelegationFull > 0 && maxTotal != nil && maxTotal.Sign() > 0 && info.TotalDelegation != nil {
+		if fraction(info.TotalDelegation, maxTotal) >= w.opts.DelegationFull {
+			conditions[AlertDelegationFull] = fmt.Sprintf(
+				"total delegation %s of max %s ITC is over %s",
+				common.FormatITC(info.TotalDelegation), common.FormatITC(maxTotal), formatPercent(w.opts.DelegationFull),
+			)
+		}
+	}
+	return conditions
+}
+
+func formatRate(rate string) string {
+	if r, ok := new(big.Rat).SetString(rate); ok {
+		f, _ := r.Float64()
+		return formatPercent(f)
+	}
+	return rate
+}
+
+// ElectedAddresses fetches the addresses of the validators elected in the
+// current epoch from node
+func ElectedAddresses(node string) ([]string, error) {
+	addresses := []string{}
+	if err := rpc.RequestResult(rpc.Method.GetElectedValidatorAddresses, node, []interface{}{}, &addresses); err != nil {
+		return nil, err
+	}
+	return addresses, nil
+}
+
+// StakingFailures fetches the entries of the node's in-memory record of
+// failed staking transactions that belong to the validator at address. The
+// sink is node wide and its entries only carry a transaction hash, so they
+// are matched against the recent staking transactions of the validator.
+func StakingFailures(node, address string) ([]StakingFailure, error) {
+	failures := []StakingFailure{}
+	if err := rpc.RequestResult(rpc.Method.GetCurrentStakingErrorSink, node, []interface{}{}, &failures); err != nil {
+		return nil, err
+	}
+	if len(failures) == 0 {
+		return failures, nil
+	}
+	params := map[string]interface{}{
+		"address": address, "pageIndex": 0, "pageSize": stakingHistoryPageSize,
+		"fullTx": true, "txType": "ALL", "order": "DESC",
+	}
+	history := struct {
+		StakingTransactions []struct {
+			Hash string `json:"hash"`
+		} `json:"staking_transactions"`
+	}{}
+	if err := rpc.RequestResult(
+		rpc.Method.GetStakingTransactionsHistory, node, []interface{}{params}, &history,
+	); err != nil {
+		return nil, err
+	}
+	own := map[string]bool{}
+	for _, tx := range history.StakingTransactions {
+		own[strings.ToLower(tx.Hash)] = true
+	}
+	mine := []StakingFailure{}
+	for _, failure := range failures {
+		if own[strings.ToLower(failure.TxHash)] {
+			mine = append(mine, failure)
+		}
+	}
+	return mine, nil
+}
@@ -0,0 +1,95 @@
+package validator
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/intelchain-itc/itc-sdk/pkg/rpc"
+)
+
+func watchInfo(rate string, signed, toSign int64, total int64, elected ...string) *Information {
+	info := &Information{}
+	info.Validator.Rate = rate
+	info.Validator.BLSPublicKeys = []string{"aa", "bb"}
+	info.Validator.MaxTotalDelegation = big.NewInt(1000)
+	info.TotalDelegation = big.NewInt(total)
+	info.Performance = &Performance{}
+	info.Performance.Signing.Signed, info.Performance.Signing.ToSign = big.NewInt(signed), big.NewInt(toSign)
+	info.Metrics = &Metrics{}
+	for _, k := range elected {
+		vote := KeyMetrics{}
+		vote.Key.BLSPublicKey = k
+		info.Metrics.ByBLSKey = append(info.Metrics.ByBLSKey, vote)
+	}
+	return info
+}
+
+type watchAlert struct {
+	kind     string
+	resolved bool
+}
+
+func TestWatcherCheck(t *testing.T) {
+	oldFailure := StakingFailure{TxHash: "0x1", Directive: "Delegate", Message: "insufficient balance"}
+	newFailure := StakingFailure{TxHash: "0x2", Directive: "EditValidator", Message: "rate too high"}
+	steps := []struct {
+		obs      Observation
+		expected []watchAlert
+	}{
+		{Observation{Info: watchInfo("0.1", 99, 100, 100, "aa", "bb"), Elected: true, Failures: []StakingFailure{oldFailure}}, []watchAlert{}},
+		{Observation{Info: watchInfo("0.1", 80, 100, 100, "aa", "bb"), Elected: true}, []watchAlert{{AlertLowSigning, false}}},
+		{Observation{Info: watchInfo("0.1", 70, 100, 100, "aa", "bb"), Elected: true}, []watchAlert{{AlertLowSigning, false}}},
+		{Observation{Info: watchInfo("0.1", 70, 100, 100, "aa", "bb"), Elected: true}, []watchAlert{}},
+		{Observation{Info: watchInfo("0.1", 99, 100, 100, "aa"), Elected: true}, []watchAlert{
+			{AlertLowSigning, true}, {AlertMissingKeys, false},
+		}},
+		{Observation{Info: watchInfo("0.2", 99, 100, 960), Elected: false, Failures: []StakingFailure{oldFailure, newFailure}}, []watchAlert{
+			{AlertDelegationFull, false}, {AlertMissingKeys, true}, {AlertNotElected, false},
+			{AlertCommissionChanged, false}, {AlertStakingFailure, false},
+		}},
+		{Observation{Info: watchInfo("0.2", 99, 100, 100, "aa", "bb"), Elected: true, Failures: []StakingFailure{newFailure}}, []watchAlert{
+			{AlertDelegationFull, true}, {AlertNotElected, true},
+		}},
+	}
+	w := NewWatcher("itc1validator", WatchOptions{MinSigning: 0.9, DelegationFull: 0.95})
+	for i, step := range steps {
+		result := []watchAlert{}
+		for _, alert := range w.Check(step.obs) {
+			if alert.Validator != "itc1validator" || alert.Message == "" {
+				t.Errorf("Check step %d returned incomplete alert %+v", i, alert)
+			}
+			result = append(result, watchAlert{alert.Kind, alert.Resolved})
+		}
+		if !reflect.DeepEqual(result, step.expected) {
+			t.Errorf("Check step %d returned %v, expected %v", i, result, step.expected)
+		}
+	}
+}
+
+func TestStakingFailuresOfValidator(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		call := map[string]interface{}{}
+		json.NewDecoder(r.Body).Decode(&call)
+		id, _ := json.Marshal(call["id"])
+		result := `[` +
+			`{"tx-hash-id":"0xAA","directive-kind":"EditValidator","error-message":"rate too high"},` +
+			`{"tx-hash-id":"0xbb","directive-kind":"Delegate","error-message":"insufficient balance"}]`
+		if call["method"] == rpc.Method.GetStakingTransactionsHistory {
+			result = `{"staking_transactions":[{"hash":"0xaa"},{"hash":"0xcc"}]}`
+		}
+		w.Write([]byte(`{"jsonrpc":"2.0","id":` + string(id) + `,"result":` + result + `}`))
+	}))
+	defer server.Close()
+
+	failures, err := StakingFailures(server.URL, "itc1validator")
+	if err != nil {
+		t.Fatalf("StakingFailures returned error %v", err)
+	}
+	if len(failures) != 1 || failures[0].TxHash != "0xAA" {
+		t.Errorf("StakingFailures returned %+v, expected only 0xAA of the validator", failures)
+	}
+}